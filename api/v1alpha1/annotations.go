@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// RolloutMatchersAnnotation holds the matchers of the silence created while a
+	// Deployment, StatefulSet or DaemonSet rollout is in progress.
+	// The value is a JSON list using the same format as Silence spec.matchers.
+	RolloutMatchersAnnotation = "silence-operator/rollout-matchers"
+
+	// RolloutCompletedAnnotation is set on rollout silences once the rollout completed.
+	// The silence is deleted after the grace period counted from this moment.
+	RolloutCompletedAnnotation = "silence-operator/rollout-completed-at"
+
+	// NodeLabel is set on node maintenance silences to the name of the node.
+	NodeLabel = "silence-operator/node"
+
+	// NamespaceSilenceUntilAnnotation silences all alerts of the annotated namespace until the given RFC3339 time.
+	NamespaceSilenceUntilAnnotation = "silence-operator/silence-until"
+
+	// NamespaceSilenceMatchersAnnotation holds additional matchers of the namespace silence.
+	// The value is a JSON list using the same format as Silence spec.matchers.
+	NamespaceSilenceMatchersAnnotation = "silence-operator/silence-matchers"
+
+	// AdoptSilenceAnnotation holds the ID of an existing alertmanager silence, e.g. of an imported one.
+	// A Silence which did not record an alertmanager silence yet takes it over instead of creating a new one.
+	AdoptSilenceAnnotation = "silence-operator/adopt-silence-id"
+)
@@ -17,40 +17,170 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+// parseMatcher accepts both the UTF-8 and the classic matcher syntax, like alertmanager does by default.
+var parseMatcher = compat.FallbackMatcherParser(slog.New(slog.DiscardHandler))
+
+// parseMatchers is the same for a list of matchers, e.g. `{alertname="Foo", severity=~"warning|info"}`.
+var parseMatchers = compat.FallbackMatchersParser(slog.New(slog.DiscardHandler))
+
+// valueEscaper escapes a quoted matcher value the same way alertmanager does.
+var valueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// labelNameRE is the Prometheus label name grammar.
+var labelNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// MatchType is the alertmanager match operator.
+// +kubebuilder:validation:Enum="=";"!=";"=~";"!~"
+type MatchType string
+
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
 )
 
+// Matcher matches a label of alerts. The operator is either given by matchType or,
+// for compatibility, by isEqual and isRegex, which default to true when left out.
+// +kubebuilder:validation:XValidation:rule="!has(self.matchType) || (!has(self.isEqual) && !has(self.isRegex))",message="matchType can't be combined with isEqual and isRegex"
 type Matcher struct {
-	// +kubebuilder:default:=true
-	IsEqual bool `json:"isEqual,omitempty"`
-	// +kubebuilder:default:=true
-	IsRegex bool   `json:"isRegex,omitempty"`
+	// MatchType is the operator comparing the label with the value.
+	// +optional
+	MatchType MatchType `json:"matchType,omitempty"`
+	// Deprecated: use matchType.
+	// +optional
+	IsEqual *bool `json:"isEqual,omitempty"`
+	// Deprecated: use matchType.
+	// +optional
+	IsRegex *bool  `json:"isRegex,omitempty"`
 	Name    string `json:"name"`
 	Value   string `json:"value"`
 }
 
+// ParseMatcher parses a single matcher in the alertmanager syntax, e.g. `severity=~"warning|info"`.
+func ParseMatcher(s string) (Matcher, error) {
+	m, err := parseMatcher(s, "silence-operator")
+	if err != nil {
+		return Matcher{}, err
+	}
+
+	return Matcher{
+		Name:      m.Name,
+		Value:     m.Value,
+		MatchType: MatchType(m.Type.String()),
+	}, nil
+}
+
+// ParseMatchers parses a list of matchers in the alertmanager syntax, e.g. `{alertname="Foo", severity=~"warning|info"}`.
+func ParseMatchers(s string) (Matchers, error) {
+	parsed, err := parseMatchers(s, "silence-operator")
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make(Matchers, 0, len(parsed))
+
+	for _, m := range parsed {
+		matchers = append(matchers, Matcher{
+			Name:      m.Name,
+			Value:     m.Value,
+			MatchType: MatchType(m.Type.String()),
+		})
+	}
+
+	return matchers, nil
+}
+
+// Type returns the alertmanager match type of the matcher.
+func (m Matcher) Type() labels.MatchType {
+	switch m.MatchType {
+	case MatchEqual:
+		return labels.MatchEqual
+	case MatchNotEqual:
+		return labels.MatchNotEqual
+	case MatchRegexp:
+		return labels.MatchRegexp
+	case MatchNotRegexp:
+		return labels.MatchNotRegexp
+	}
+
+	isEqual := m.IsEqual == nil || *m.IsEqual
+	isRegex := m.IsRegex == nil || *m.IsRegex
+
+	switch {
+	case isEqual && isRegex:
+		return labels.MatchRegexp
+	case !isEqual && isRegex:
+		return labels.MatchNotRegexp
+	case isEqual && !isRegex:
+		return labels.MatchEqual
+	}
+
+	return labels.MatchNotEqual
+}
+
+// String returns the matcher in the alertmanager syntax. The value is always quoted and escaped,
+// so it may contain any character and is parsed back by alertmanager unchanged.
+func (m Matcher) String() string {
+	return fmt.Sprintf(`%s%s"%s"`, m.Name, m.Type(), valueEscaper.Replace(m.Value))
+}
+
+// Validate checks the label name against the Prometheus label name grammar
+// and, for regex matchers, that the value is a valid regular expression.
+func (m Matcher) Validate() error {
+	if !labelNameRE.MatchString(m.Name) {
+		return fmt.Errorf("invalid label name %q", m.Name)
+	}
+
+	if _, err := labels.NewMatcher(m.Type(), m.Name, m.Value); err != nil {
+		return fmt.Errorf("matcher %s: %w", m, err)
+	}
+
+	return nil
+}
+
 type Matchers []Matcher
 
+// String returns the matchers in the alertmanager syntax, as used by the silences filter.
 func (m Matchers) String() []string {
 	out := make([]string, 0, len(m))
 
 	for _, matcher := range m {
-		var operator string
-
-		switch {
-		case matcher.IsEqual && matcher.IsRegex:
-			operator = "=~"
-		case !matcher.IsEqual && matcher.IsRegex:
-			operator = "!~"
-		case matcher.IsEqual && !matcher.IsRegex:
-			operator = "="
-		case !matcher.IsEqual && !matcher.IsRegex:
-			operator = "!="
-		}
-
-		filter := fmt.Sprintf("%s%s%s", matcher.Name, operator, matcher.Value)
-		out = append(out, filter)
+		out = append(out, matcher.String())
 	}
 
 	return out
 }
+
+// Key identifies the matchers independent of their order and of the way their operator is given.
+func (m Matchers) Key() string {
+	matchers := m.String()
+	slices.Sort(matchers)
+
+	sum := sha256.Sum256([]byte(strings.Join(matchers, "\n")))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// Validate returns the error of the first invalid matcher.
+func (m Matchers) Validate() error {
+	for _, matcher := range m {
+		if err := matcher.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Matcher
+		wantErr bool
+	}{
+		{input: `alertname="Foo"`, want: Matcher{Name: "alertname", Value: "Foo", MatchType: MatchEqual}},
+		{input: `severity=~"warn|info"`, want: Matcher{Name: "severity", Value: "warn|info", MatchType: MatchRegexp}},
+		{input: `env!="prod"`, want: Matcher{Name: "env", Value: "prod", MatchType: MatchNotEqual}},
+		{input: `job!~"node.*"`, want: Matcher{Name: "job", Value: "node.*", MatchType: MatchNotRegexp}},
+		{input: `alertname=Foo`, want: Matcher{Name: "alertname", Value: "Foo", MatchType: MatchEqual}},
+		{input: `comment="a \"quoted\", value"`, want: Matcher{Name: "comment", Value: `a "quoted", value`, MatchType: MatchEqual}},
+		{input: `alertname`, wantErr: true},
+		{input: `job=~"("`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMatcher(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseMatcher() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchersString(t *testing.T) {
+	matchers := Matchers{
+		{Name: "alertname", Value: "Foo", MatchType: MatchEqual},
+		{Name: "severity", Value: "warn|info", MatchType: MatchRegexp},
+		{Name: "comment", Value: "a \"quoted\", value\\with\nnewline", MatchType: MatchNotEqual},
+	}
+
+	want := []string{
+		`alertname="Foo"`,
+		`severity=~"warn|info"`,
+		`comment!="a \"quoted\", value\\with\nnewline"`,
+	}
+
+	got := matchers.String()
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("String()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMatcherStringRoundTrip(t *testing.T) {
+	values := []string{
+		"",
+		"Foo",
+		"with space",
+		"a,b",
+		`a "quoted" value`,
+		"closing}brace",
+		"{opening",
+		`back\slash`,
+		`trailing\`,
+		"new\nline",
+		"unicode ✓",
+		`=~!"`,
+	}
+
+	for _, value := range values {
+		for _, m := range []Matcher{
+			{Name: "label", Value: value, MatchType: MatchEqual},
+			{Name: "label", Value: value, MatchType: MatchNotEqual},
+			{Name: "label", Value: value, MatchType: MatchRegexp},
+			{Name: "label", Value: value, MatchType: MatchNotRegexp},
+		} {
+			if m.Validate() != nil {
+				// Not a valid regular expression, alertmanager refuses it anyway
+				continue
+			}
+
+			encoded := m.String()
+
+			// The classic parser used by amtool and older alertmanager versions
+			classic, err := labels.ParseMatcher(encoded)
+			if err != nil {
+				t.Errorf("labels.ParseMatcher(%s) error = %v", encoded, err)
+
+				continue
+			}
+
+			if classic.Name != m.Name || classic.Value != m.Value || classic.Type != m.Type() {
+				t.Errorf("labels.ParseMatcher(%s) = %s, want %s", encoded, classic, m)
+			}
+
+			// The default parser of the alertmanager API, which is used for the silences filter
+			parsed, err := ParseMatcher(encoded)
+			if err != nil {
+				t.Errorf("ParseMatcher(%s) error = %v", encoded, err)
+
+				continue
+			}
+
+			if parsed != m {
+				t.Errorf("ParseMatcher(%s) = %+v, want %+v", encoded, parsed, m)
+			}
+		}
+	}
+}
+
+func TestMatcherType(t *testing.T) {
+	tests := []struct {
+		matcher Matcher
+		want    labels.MatchType
+	}{
+		{matcher: Matcher{MatchType: MatchNotEqual}, want: labels.MatchNotEqual},
+		{matcher: Matcher{}, want: labels.MatchRegexp},
+		{matcher: Matcher{IsRegex: ptr.To(false)}, want: labels.MatchEqual},
+		{matcher: Matcher{IsEqual: ptr.To(false)}, want: labels.MatchNotRegexp},
+		{matcher: Matcher{IsEqual: ptr.To(false), IsRegex: ptr.To(false)}, want: labels.MatchNotEqual},
+	}
+
+	for _, tt := range tests {
+		if got := tt.matcher.Type(); got != tt.want {
+			t.Errorf("%+v.Type() = %s, want %s", tt.matcher, got, tt.want)
+		}
+	}
+}
+
+func TestMatcherValidate(t *testing.T) {
+	tests := []struct {
+		matcher Matcher
+		wantErr bool
+	}{
+		{matcher: Matcher{Name: "alertname", Value: "Foo"}},
+		{matcher: Matcher{Name: "_private", Value: "Foo"}},
+		{matcher: Matcher{Name: "severity", Value: "warn|info", MatchType: MatchNotRegexp}},
+		{matcher: Matcher{Name: "", Value: "Foo"}, wantErr: true},
+		{matcher: Matcher{Name: "1st", Value: "Foo"}, wantErr: true},
+		{matcher: Matcher{Name: "with-dash", Value: "Foo"}, wantErr: true},
+		{matcher: Matcher{Name: "with.dot", Value: "Foo"}, wantErr: true},
+		{matcher: Matcher{Name: "job", Value: "(", MatchType: MatchNotRegexp}, wantErr: true},
+		{matcher: Matcher{Name: "job", Value: "(", MatchType: MatchNotEqual}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.matcher.String(), func(t *testing.T) {
+			if err := tt.matcher.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchersKey(t *testing.T) {
+	a := Matchers{
+		{Name: "alertname", Value: "Foo", MatchType: MatchEqual},
+		{Name: "env", Value: "prod|staging", MatchType: MatchRegexp},
+	}
+	reordered := Matchers{
+		{Name: "env", Value: "prod|staging"},
+		{Name: "alertname", Value: "Foo", IsRegex: ptr.To(false)},
+	}
+
+	if a.Key() != reordered.Key() {
+		t.Errorf("expected the same key for reordered matchers, got %s and %s", a.Key(), reordered.Key())
+	}
+
+	changed := Matchers{a[0], {Name: "env", Value: "prod|staging", MatchType: MatchNotRegexp}}
+
+	if a.Key() == changed.Key() {
+		t.Error("expected a different key for a different operator")
+	}
+}
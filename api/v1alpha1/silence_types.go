@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,13 +27,147 @@ const (
 	SilenceFinalizer = "monitoring.coreos.com/Silence"
 )
 
+const (
+	// ConditionExpired is true when the silence reached its maximum lifetime or endsAt and is not extended anymore.
+	ConditionExpired = "Expired"
+
+	// ConditionTemplateRendered is false when the referenced SilenceTemplate is missing or can't be rendered.
+	ConditionTemplateRendered = "TemplateRendered"
+
+	// ConditionMatchersValid is false when spec.matcherExpressions can't be parsed
+	// or a matcher has an invalid label name or regular expression.
+	ConditionMatchersValid = "MatchersValid"
+
+	// ConditionAlertResolved is false when the alert referenced by spec.fromAlert can't be found.
+	ConditionAlertResolved = "AlertResolved"
+
+	// ConditionMatchesNothing is true when the silence has not muted any alert for the configured period.
+	ConditionMatchesNothing = "MatchesNothing"
+
+	// ConditionDryRun is true while the silence is only previewed and not applied to alertmanager.
+	ConditionDryRun = "DryRun"
+
+	// ConditionSuspended is true while spec.suspend is set and the alertmanager silence is expired.
+	ConditionSuspended = "Suspended"
+)
+
+// DeletionPolicy decides what happens to the alertmanager silence when the Silence is deleted.
+// +kubebuilder:validation:Enum=Expire;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyExpire expires the alertmanager silence.
+	DeletionPolicyExpire DeletionPolicy = "Expire"
+
+	// DeletionPolicyOrphan leaves the alertmanager silence running until it ends on its own.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 // SilenceSpec defines the desired state of Silence.
+// +kubebuilder:validation:XValidation:rule="(has(self.matchers) && size(self.matchers) > 0) || (has(self.matcherExpressions) && size(self.matcherExpressions) > 0) || has(self.templateRef) || has(self.fromAlert)",message="at least one of matchers, matcherExpressions, templateRef or fromAlert is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.matchers) || size(self.matchers) == 0 || has(self.matcherExpressions) || has(self.templateRef) || has(self.fromAlert) || self.matchers.exists(m, has(m.matchType) ? m.matchType in ['=', '=~'] : !has(m.isEqual) || m.isEqual)",message="at least one matcher must be a positive = or =~ match"
 type SilenceSpec struct {
-	Comment  string   `json:"comment"`
-	Matchers Matchers `json:"matchers"`
+	Comment string `json:"comment"`
+
+	// +optional
+	Matchers Matchers `json:"matchers,omitempty"`
+
+	// MatcherExpressions are matchers in the alertmanager syntax, e.g. `severity=~"warning|info"`.
+	// They are combined with the other matchers.
+	// +optional
+	MatcherExpressions []string `json:"matcherExpressions,omitempty"`
+
+	// TemplateRef renders additional matchers from a SilenceTemplate in the same namespace.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+
+	// FromAlert derives equality matchers from the labels of a currently firing alert.
+	// The alert is looked up once, the derived matchers are kept in the status after it resolves.
+	// +optional
+	FromAlert *AlertReference `json:"fromAlert,omitempty"`
+
+	// Duration is the length of the rolling alertmanager silence window.
+	// The silence is extended before it ends, so it is kept active while the object exists.
+	// Defaults to the operator's --silence-duration.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 
+	// MaxLifetime limits how long after the object creation the silence is kept active.
+	// Once reached, the silence is not extended anymore and the Expired condition is set.
+	// +optional
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+
+	// EndsAt is the moment after which the silence is not extended anymore.
+	// Once reached, the Expired condition is set.
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+
+	// Suspend temporarily disables the silence: the alertmanager silence is expired
+	// and created again once suspend is unset.
 	// +kubebuilder:default:=false
 	Suspend bool `json:"suspend,omitempty"`
+
+	// DeletionPolicy decides whether the alertmanager silence is expired or left running
+	// when the object is deleted.
+	// +kubebuilder:default:=Expire
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DryRun previews the silence: the matchers are evaluated against the current alerts
+	// and the alerts which would be silenced are reported in the status, but nothing is posted to alertmanager.
+	// An alertmanager silence applied before is not extended anymore.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// TemplateReference references a SilenceTemplate and provides values for its placeholders.
+type TemplateReference struct {
+	// Name of the SilenceTemplate in the same namespace.
+	Name string `json:"name"`
+
+	// Parameters are available in the template matchers as {{ .<parameter> }}.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AlertReference identifies a firing alert either by its fingerprint or by its name and a subset of its labels.
+// +kubebuilder:validation:XValidation:rule="has(self.fingerprint) || has(self.alertName)",message="either fingerprint or alertName is required"
+type AlertReference struct {
+	// Fingerprint of the alert as reported by alertmanager.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// AlertName is the alertname label of the alert.
+	// +optional
+	AlertName string `json:"alertName,omitempty"`
+
+	// Labels the alert must have in addition to the alertname.
+	// They must identify a single alert.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// IncludeLabels limits the derived matchers to the given label names.
+	// All labels of the alert are used if empty.
+	// +optional
+	IncludeLabels []string `json:"includeLabels,omitempty"`
+
+	// ExcludeLabels are never turned into matchers.
+	// +optional
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+}
+
+// ResolvedAlert records the alert found for spec.fromAlert and the matchers derived from it.
+type ResolvedAlert struct {
+	// Reference is the spec.fromAlert the alert was looked up for.
+	Reference   AlertReference `json:"reference"`
+	Fingerprint string         `json:"fingerprint"`
+	Matchers    Matchers       `json:"matchers"`
+}
+
+// MutedAlert identifies an alert muted by the silence.
+type MutedAlert struct {
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
 }
 
 // SilenceStatus defines the observed state of Silence.
@@ -39,10 +175,56 @@ type SilenceStatus struct {
 	Active                bool   `json:"active,omitempty"`
 	AlertManagerID        string `json:"alertmanager_id,omitempty"`
 	LastAppliedGeneration int64  `json:"last_applied_generation,omitempty"`
+
+	// Matchers summarizes the applied matchers in the alertmanager syntax.
+	// +optional
+	Matchers string `json:"matchers,omitempty"`
+
+	// AppliedMatchers are the matchers of the alertmanager silence, including the rendered ones.
+	// +optional
+	AppliedMatchers Matchers `json:"applied_matchers,omitempty"`
+
+	// FromAlert is set once the alert referenced by spec.fromAlert was found.
+	// +optional
+	FromAlert *ResolvedAlert `json:"from_alert,omitempty"`
+
+	// MutedAlerts is the number of alerts muted by the alertmanager silence at the last check.
+	// +optional
+	MutedAlerts int `json:"muted_alerts,omitempty"`
+
+	// MutedAlertSamples lists some of the muted alerts.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MutedAlertSamples []MutedAlert `json:"muted_alert_samples,omitempty"`
+
+	// MatchingNothingSince is when the silence stopped muting alerts, unset while it mutes any.
+	// +optional
+	MatchingNothingSince *metav1.Time `json:"matching_nothing_since,omitempty"`
+
+	// MatchingAlerts is the number of alerts the silence would mute, reported in dry run.
+	// +optional
+	MatchingAlerts int `json:"matching_alerts,omitempty"`
+
+	// MatchingAlertSamples lists some of the alerts the silence would mute, reported in dry run.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MatchingAlertSamples []MutedAlert `json:"matching_alert_samples,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="AlertmanagerID",type=string,JSONPath=`.status.alertmanager_id`
+// +kubebuilder:printcolumn:name="EndsAt",type=date,JSONPath=`.spec.endsAt`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Matchers",type=string,JSONPath=`.status.matchers`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Silence is the Schema for the silences API.
 type Silence struct {
@@ -53,6 +235,24 @@ type Silence struct {
 	Status SilenceStatus `json:"status,omitempty"`
 }
 
+// ExpiresAt returns the moment after which the silence must not be active anymore,
+// or nil if its lifetime is not limited. It is the earliest of endsAt and the maximum lifetime.
+func (s *Silence) ExpiresAt() *time.Time {
+	var expiresAt *time.Time
+
+	if s.Spec.MaxLifetime != nil {
+		t := s.CreationTimestamp.Add(s.Spec.MaxLifetime.Duration)
+		expiresAt = &t
+	}
+
+	if s.Spec.EndsAt != nil && (expiresAt == nil || s.Spec.EndsAt.Time.Before(*expiresAt)) {
+		t := s.Spec.EndsAt.Time
+		expiresAt = &t
+	}
+
+	return expiresAt
+}
+
 // +kubebuilder:object:root=true
 
 // SilenceList contains a list of Silence.
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestSilenceExpiresAt(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec SilenceSpec
+		want *time.Time
+	}{
+		{
+			name: "no lifetime",
+		},
+		{
+			name: "max lifetime",
+			spec: SilenceSpec{MaxLifetime: &metav1.Duration{Duration: 24 * time.Hour}},
+			want: ptr.To(created.Add(24 * time.Hour)),
+		},
+		{
+			name: "end",
+			spec: SilenceSpec{EndsAt: &metav1.Time{Time: created.Add(2 * time.Hour)}},
+			want: ptr.To(created.Add(2 * time.Hour)),
+		},
+		{
+			name: "end before max lifetime",
+			spec: SilenceSpec{
+				MaxLifetime: &metav1.Duration{Duration: 24 * time.Hour},
+				EndsAt:      &metav1.Time{Time: created.Add(2 * time.Hour)},
+			},
+			want: ptr.To(created.Add(2 * time.Hour)),
+		},
+		{
+			name: "max lifetime before end",
+			spec: SilenceSpec{
+				MaxLifetime: &metav1.Duration{Duration: time.Hour},
+				EndsAt:      &metav1.Time{Time: created.Add(2 * time.Hour)},
+			},
+			want: ptr.To(created.Add(time.Hour)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Silence{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}, Spec: tt.spec}
+
+			got := s.ExpiresAt()
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("ExpiresAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
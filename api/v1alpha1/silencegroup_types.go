@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SilenceGroupKind      = "SilenceGroup"
+	SilenceGroupFinalizer = "monitoring.coreos.com/SilenceGroup"
+)
+
+const (
+	// ConditionApplied is false when not all silences of the group could be applied.
+	// Silences created during the failed attempt are rolled back.
+	ConditionApplied = "Applied"
+)
+
+// SilenceGroupSpec defines the desired state of SilenceGroup.
+type SilenceGroupSpec struct {
+	Comment string `json:"comment"`
+
+	// MatcherSets are silenced independently: alertmanager matchers are AND-ed,
+	// so every matcher set gets its own alertmanager silence.
+	// +kubebuilder:validation:MinItems=1
+	MatcherSets []Matchers `json:"matcherSets"`
+
+	// Duration is the length of the rolling alertmanager silence window.
+	// Defaults to the operator's --silence-duration.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Suspend temporarily disables the group: its alertmanager silences are expired
+	// and created again once suspend is unset.
+	// +kubebuilder:default:=false
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DryRun previews the group: the matcher sets are evaluated against the current alerts
+	// and the alerts which would be silenced are reported in the status, but nothing is posted to alertmanager.
+	// Alertmanager silences applied before are not extended anymore.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// SilenceGroupSilence is the alertmanager silence of a matcher set.
+type SilenceGroupSilence struct {
+	// Key identifies the matcher set, see Matchers.Key. It doesn't change when the matcher sets are reordered.
+	Key            string `json:"key"`
+	AlertManagerID string `json:"alertmanager_id"`
+}
+
+// SilenceGroupStatus defines the observed state of SilenceGroup.
+type SilenceGroupStatus struct {
+	// Silences are the alertmanager silences of the matcher sets.
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	Silences []SilenceGroupSilence `json:"silences,omitempty"`
+
+	LastAppliedGeneration int64 `json:"last_applied_generation,omitempty"`
+
+	// MatchingAlerts is the number of alerts the silences of the group would mute, reported in dry run.
+	// +optional
+	MatchingAlerts int `json:"matching_alerts,omitempty"`
+
+	// MatchingAlertSamples lists some of the alerts the silences of the group would mute, reported in dry run.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MatchingAlertSamples []MutedAlert `json:"matching_alert_samples,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SilenceGroup is the Schema for the silencegroups API.
+// It manages one alertmanager silence per matcher set.
+type SilenceGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SilenceGroupSpec   `json:"spec,omitempty"`
+	Status SilenceGroupStatus `json:"status,omitempty"`
+}
+
+// Member returns the silence managed for the matcher set at the given index.
+// It only exists in memory and carries the alertmanager ID recorded in the status for the matcher set.
+func (g *SilenceGroup) Member(i int) *Silence {
+	s := &Silence{
+		ObjectMeta: *g.ObjectMeta.DeepCopy(),
+		Spec: SilenceSpec{
+			Comment:  g.Spec.Comment,
+			Matchers: g.Spec.MatcherSets[i].DeepCopy(),
+			Duration: g.Spec.Duration.DeepCopy(),
+		},
+	}
+
+	s.Status.AlertManagerID = g.RecordedSilences()[g.Spec.MatcherSets[i].Key()]
+
+	return s
+}
+
+// RecordedSilences returns the recorded alertmanager silence IDs by matcher set key.
+func (g *SilenceGroup) RecordedSilences() map[string]string {
+	recorded := map[string]string{}
+
+	for _, s := range g.Status.Silences {
+		recorded[s.Key] = s.AlertManagerID
+	}
+
+	return recorded
+}
+
+// RecordedIDs returns all alertmanager silence IDs recorded in the status.
+func (s *SilenceGroupStatus) RecordedIDs() []string {
+	ids := make([]string, 0, len(s.Silences))
+
+	for _, silence := range s.Silences {
+		ids = append(ids, silence.AlertManagerID)
+	}
+
+	return ids
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceGroupList contains a list of SilenceGroup.
+type SilenceGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SilenceGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SilenceGroup{}, &SilenceGroupList{})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SilenceTemplateKind = "SilenceTemplate"
+)
+
+// SilenceTemplateSpec defines the desired state of SilenceTemplate.
+type SilenceTemplateSpec struct {
+	// Matchers names and values may contain Go template placeholders, e.g. {{ .env }},
+	// which are rendered with the parameters of the referencing Silence.
+	Matchers Matchers `json:"matchers"`
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceTemplate is the Schema for the silencetemplates API.
+type SilenceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SilenceTemplateSpec `json:"spec,omitempty"`
+}
+
+// Render returns the template matchers with the placeholders replaced by the given parameters.
+// Referencing a parameter which is not provided is an error.
+func (t *SilenceTemplate) Render(parameters map[string]string) (Matchers, error) {
+	if parameters == nil {
+		parameters = map[string]string{}
+	}
+
+	render := func(text string) (string, error) {
+		tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", err
+		}
+
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, parameters); err != nil {
+			return "", err
+		}
+
+		return buf.String(), nil
+	}
+
+	out := make(Matchers, 0, len(t.Spec.Matchers))
+
+	for _, m := range t.Spec.Matchers {
+		name, err := render(m.Name)
+		if err != nil {
+			return nil, fmt.Errorf("matcher name %q: %w", m.Name, err)
+		}
+
+		value, err := render(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("matcher %q value: %w", m.Name, err)
+		}
+
+		m.Name = name
+		m.Value = value
+		out = append(out, m)
+	}
+
+	return out, nil
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceTemplateList contains a list of SilenceTemplate.
+type SilenceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SilenceTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SilenceTemplate{}, &SilenceTemplateList{})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSilenceTemplateRender(t *testing.T) {
+	tmpl := &SilenceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance"},
+		Spec: SilenceTemplateSpec{Matchers: Matchers{
+			{Name: "{{ .label }}", Value: "{{ .value }}", MatchType: MatchEqual},
+			{Name: "env", Value: "{{ .env }}|staging", MatchType: MatchRegexp},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		want       Matchers
+		wantErr    bool
+		invalid    bool
+	}{
+		{
+			name:       "names and values",
+			parameters: map[string]string{"label": "cluster", "value": "eu-1", "env": "prod"},
+			want: Matchers{
+				{Name: "cluster", Value: "eu-1", MatchType: MatchEqual},
+				{Name: "env", Value: "prod|staging", MatchType: MatchRegexp},
+			},
+		},
+		{
+			name:       "missing parameter",
+			parameters: map[string]string{"label": "cluster", "value": "eu-1"},
+			wantErr:    true,
+		},
+		{
+			name:    "no parameters",
+			wantErr: true,
+		},
+		{
+			name:       "invalid label name",
+			parameters: map[string]string{"label": "not a label", "value": "eu-1", "env": "prod"},
+			want: Matchers{
+				{Name: "not a label", Value: "eu-1", MatchType: MatchEqual},
+				{Name: "env", Value: "prod|staging", MatchType: MatchRegexp},
+			},
+			invalid: true,
+		},
+		{
+			name:       "invalid regex",
+			parameters: map[string]string{"label": "cluster", "value": "eu-1", "env": "("},
+			want: Matchers{
+				{Name: "cluster", Value: "eu-1", MatchType: MatchEqual},
+				{Name: "env", Value: "(|staging", MatchType: MatchRegexp},
+			},
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.Render(tt.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Render() = %+v, want %+v", got, tt.want)
+			}
+
+			// The rendered matchers are validated together with the other matchers of the silence
+			if err := got.Validate(); (err != nil) != tt.invalid {
+				t.Errorf("Validate() error = %v, invalid %v", err, tt.invalid)
+			}
+		})
+	}
+}
+
+func TestSilenceTemplateRenderInvalidTemplate(t *testing.T) {
+	tmpl := &SilenceTemplate{Spec: SilenceTemplateSpec{Matchers: Matchers{
+		{Name: "env", Value: "{{ .env", MatchType: MatchEqual},
+	}}}
+
+	if _, err := tmpl.Render(map[string]string{"env": "prod"}); err == nil {
+		t.Fatal("expected an unparsable template to fail")
+	}
+}
@@ -21,12 +21,55 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertReference) DeepCopyInto(out *AlertReference) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IncludeLabels != nil {
+		in, out := &in.IncludeLabels, &out.IncludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLabels != nil {
+		in, out := &in.ExcludeLabels, &out.ExcludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertReference.
+func (in *AlertReference) DeepCopy() *AlertReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Matcher) DeepCopyInto(out *Matcher) {
 	*out = *in
+	if in.IsEqual != nil {
+		in, out := &in.IsEqual, &out.IsEqual
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsRegex != nil {
+		in, out := &in.IsRegex, &out.IsRegex
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matcher.
@@ -44,7 +87,9 @@ func (in Matchers) DeepCopyInto(out *Matchers) {
 	{
 		in := &in
 		*out = make(Matchers, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -58,13 +103,51 @@ func (in Matchers) DeepCopy() Matchers {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutedAlert) DeepCopyInto(out *MutedAlert) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutedAlert.
+func (in *MutedAlert) DeepCopy() *MutedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(MutedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedAlert) DeepCopyInto(out *ResolvedAlert) {
+	*out = *in
+	in.Reference.DeepCopyInto(&out.Reference)
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make(Matchers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedAlert.
+func (in *ResolvedAlert) DeepCopy() *ResolvedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Silence) DeepCopyInto(out *Silence) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Silence.
@@ -85,6 +168,145 @@ func (in *Silence) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceGroup) DeepCopyInto(out *SilenceGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceGroup.
+func (in *SilenceGroup) DeepCopy() *SilenceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SilenceGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceGroupList) DeepCopyInto(out *SilenceGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SilenceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceGroupList.
+func (in *SilenceGroupList) DeepCopy() *SilenceGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SilenceGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceGroupSilence) DeepCopyInto(out *SilenceGroupSilence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceGroupSilence.
+func (in *SilenceGroupSilence) DeepCopy() *SilenceGroupSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceGroupSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceGroupSpec) DeepCopyInto(out *SilenceGroupSpec) {
+	*out = *in
+	if in.MatcherSets != nil {
+		in, out := &in.MatcherSets, &out.MatcherSets
+		*out = make([]Matchers, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(Matchers, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+		}
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceGroupSpec.
+func (in *SilenceGroupSpec) DeepCopy() *SilenceGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceGroupStatus) DeepCopyInto(out *SilenceGroupStatus) {
+	*out = *in
+	if in.Silences != nil {
+		in, out := &in.Silences, &out.Silences
+		*out = make([]SilenceGroupSilence, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchingAlertSamples != nil {
+		in, out := &in.MatchingAlertSamples, &out.MatchingAlertSamples
+		*out = make([]MutedAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceGroupStatus.
+func (in *SilenceGroupStatus) DeepCopy() *SilenceGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SilenceList) DeepCopyInto(out *SilenceList) {
 	*out = *in
@@ -123,8 +345,39 @@ func (in *SilenceSpec) DeepCopyInto(out *SilenceSpec) {
 	if in.Matchers != nil {
 		in, out := &in.Matchers, &out.Matchers
 		*out = make(Matchers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatcherExpressions != nil {
+		in, out := &in.MatcherExpressions, &out.MatcherExpressions
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromAlert != nil {
+		in, out := &in.FromAlert, &out.FromAlert
+		*out = new(AlertReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceSpec.
@@ -140,6 +393,39 @@ func (in *SilenceSpec) DeepCopy() *SilenceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SilenceStatus) DeepCopyInto(out *SilenceStatus) {
 	*out = *in
+	if in.AppliedMatchers != nil {
+		in, out := &in.AppliedMatchers, &out.AppliedMatchers
+		*out = make(Matchers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FromAlert != nil {
+		in, out := &in.FromAlert, &out.FromAlert
+		*out = new(ResolvedAlert)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MutedAlertSamples != nil {
+		in, out := &in.MutedAlertSamples, &out.MutedAlertSamples
+		*out = make([]MutedAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchingNothingSince != nil {
+		in, out := &in.MatchingNothingSince, &out.MatchingNothingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.MatchingAlertSamples != nil {
+		in, out := &in.MatchingAlertSamples, &out.MatchingAlertSamples
+		*out = make([]MutedAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceStatus.
@@ -151,3 +437,105 @@ func (in *SilenceStatus) DeepCopy() *SilenceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceTemplate) DeepCopyInto(out *SilenceTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceTemplate.
+func (in *SilenceTemplate) DeepCopy() *SilenceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SilenceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceTemplateList) DeepCopyInto(out *SilenceTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SilenceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceTemplateList.
+func (in *SilenceTemplateList) DeepCopy() *SilenceTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SilenceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceTemplateSpec) DeepCopyInto(out *SilenceTemplateSpec) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make(Matchers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceTemplateSpec.
+func (in *SilenceTemplateSpec) DeepCopy() *SilenceTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// MatchType is the alertmanager match operator.
+// +kubebuilder:validation:Enum="=";"!=";"=~";"!~"
+type MatchType string
+
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
+)
+
+type Matcher struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+
+	// MatchType is the operator comparing the label with the value.
+	// +kubebuilder:default:="="
+	// +optional
+	MatchType MatchType `json:"matchType,omitempty"`
+}
+
+type Matchers []Matcher
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this Silence (v1beta1) to the Hub version (v1alpha1).
+func (src *Silence) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*monitoringv1alpha1.Silence)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = monitoringv1alpha1.SilenceSpec{
+		Comment:            src.Spec.Comment,
+		Matchers:           matchersToHub(src.Spec.Matchers),
+		MatcherExpressions: src.Spec.MatcherExpressions,
+		Duration:           src.Spec.Duration,
+		MaxLifetime:        src.Spec.MaxLifetime,
+		EndsAt:             src.Spec.EndsAt,
+		Suspend:            src.Spec.Suspend,
+		DeletionPolicy:     monitoringv1alpha1.DeletionPolicy(src.Spec.DeletionPolicy),
+		DryRun:             src.Spec.DryRun,
+	}
+
+	if ref := src.Spec.TemplateRef; ref != nil {
+		dst.Spec.TemplateRef = &monitoringv1alpha1.TemplateReference{Name: ref.Name, Parameters: ref.Parameters}
+	}
+
+	if ref := src.Spec.FromAlert; ref != nil {
+		dst.Spec.FromAlert = (*monitoringv1alpha1.AlertReference)(ref)
+	}
+
+	dst.Status = monitoringv1alpha1.SilenceStatus{
+		Active:                src.Status.Active,
+		AlertManagerID:        src.Status.AlertManagerID,
+		LastAppliedGeneration: src.Status.LastAppliedGeneration,
+		Matchers:              src.Status.Matchers,
+		AppliedMatchers:       matchersToHub(src.Status.AppliedMatchers),
+		MutedAlerts:           src.Status.MutedAlerts,
+		MutedAlertSamples:     mutedAlertsToHub(src.Status.MutedAlertSamples),
+		MatchingNothingSince:  src.Status.MatchingNothingSince,
+		MatchingAlerts:        src.Status.MatchingAlerts,
+		MatchingAlertSamples:  mutedAlertsToHub(src.Status.MatchingAlertSamples),
+		Conditions:            src.Status.Conditions,
+	}
+
+	if resolved := src.Status.FromAlert; resolved != nil {
+		dst.Status.FromAlert = &monitoringv1alpha1.ResolvedAlert{
+			Reference:   monitoringv1alpha1.AlertReference(resolved.Reference),
+			Fingerprint: resolved.Fingerprint,
+			Matchers:    matchersToHub(resolved.Matchers),
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *Silence) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*monitoringv1alpha1.Silence)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = SilenceSpec{
+		Comment:            src.Spec.Comment,
+		Matchers:           matchersFromHub(src.Spec.Matchers),
+		MatcherExpressions: src.Spec.MatcherExpressions,
+		Duration:           src.Spec.Duration,
+		MaxLifetime:        src.Spec.MaxLifetime,
+		EndsAt:             src.Spec.EndsAt,
+		Suspend:            src.Spec.Suspend,
+		DeletionPolicy:     DeletionPolicy(src.Spec.DeletionPolicy),
+		DryRun:             src.Spec.DryRun,
+	}
+
+	if ref := src.Spec.TemplateRef; ref != nil {
+		dst.Spec.TemplateRef = &TemplateReference{Name: ref.Name, Parameters: ref.Parameters}
+	}
+
+	if ref := src.Spec.FromAlert; ref != nil {
+		dst.Spec.FromAlert = (*AlertReference)(ref)
+	}
+
+	dst.Status = SilenceStatus{
+		Active:                src.Status.Active,
+		AlertManagerID:        src.Status.AlertManagerID,
+		LastAppliedGeneration: src.Status.LastAppliedGeneration,
+		Matchers:              src.Status.Matchers,
+		AppliedMatchers:       matchersFromHub(src.Status.AppliedMatchers),
+		MutedAlerts:           src.Status.MutedAlerts,
+		MutedAlertSamples:     mutedAlertsFromHub(src.Status.MutedAlertSamples),
+		MatchingNothingSince:  src.Status.MatchingNothingSince,
+		MatchingAlerts:        src.Status.MatchingAlerts,
+		MatchingAlertSamples:  mutedAlertsFromHub(src.Status.MatchingAlertSamples),
+		Conditions:            src.Status.Conditions,
+	}
+
+	if resolved := src.Status.FromAlert; resolved != nil {
+		dst.Status.FromAlert = &ResolvedAlert{
+			Reference:   AlertReference(resolved.Reference),
+			Fingerprint: resolved.Fingerprint,
+			Matchers:    matchersFromHub(resolved.Matchers),
+		}
+	}
+
+	return nil
+}
+
+func matchersToHub(matchers Matchers) monitoringv1alpha1.Matchers {
+	if matchers == nil {
+		return nil
+	}
+
+	out := make(monitoringv1alpha1.Matchers, 0, len(matchers))
+
+	for _, m := range matchers {
+		matchType := monitoringv1alpha1.MatchType(m.MatchType)
+		if matchType == "" {
+			matchType = monitoringv1alpha1.MatchEqual
+		}
+
+		out = append(out, monitoringv1alpha1.Matcher{
+			Name:      m.Name,
+			Value:     m.Value,
+			MatchType: matchType,
+		})
+	}
+
+	return out
+}
+
+func matchersFromHub(matchers monitoringv1alpha1.Matchers) Matchers {
+	if matchers == nil {
+		return nil
+	}
+
+	out := make(Matchers, 0, len(matchers))
+
+	for _, m := range matchers {
+		out = append(out, Matcher{
+			Name:      m.Name,
+			Value:     m.Value,
+			MatchType: MatchType(m.Type().String()),
+		})
+	}
+
+	return out
+}
+
+func mutedAlertsToHub(alerts []MutedAlert) []monitoringv1alpha1.MutedAlert {
+	if alerts == nil {
+		return nil
+	}
+
+	out := make([]monitoringv1alpha1.MutedAlert, 0, len(alerts))
+
+	for _, a := range alerts {
+		out = append(out, monitoringv1alpha1.MutedAlert(a))
+	}
+
+	return out
+}
+
+func mutedAlertsFromHub(alerts []monitoringv1alpha1.MutedAlert) []MutedAlert {
+	if alerts == nil {
+		return nil
+	}
+
+	out := make([]MutedAlert, 0, len(alerts))
+
+	for _, a := range alerts {
+		out = append(out, MutedAlert(a))
+	}
+
+	return out
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestSilenceConversionRoundTrip(t *testing.T) {
+	hub := &monitoringv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "default", Generation: 3},
+		Spec: monitoringv1alpha1.SilenceSpec{
+			Comment: "database maintenance",
+			Matchers: monitoringv1alpha1.Matchers{
+				{Name: "alertname", Value: "DatabaseDown", MatchType: monitoringv1alpha1.MatchEqual},
+				{Name: "severity", Value: "warning|critical", MatchType: monitoringv1alpha1.MatchRegexp},
+				{Name: "env", Value: "dev", MatchType: monitoringv1alpha1.MatchNotEqual},
+				{Name: "job", Value: "node.*", MatchType: monitoringv1alpha1.MatchNotRegexp},
+			},
+			MatcherExpressions: []string{`team="db"`},
+			FromAlert:          &monitoringv1alpha1.AlertReference{Fingerprint: "abc", ExcludeLabels: []string{"pod"}},
+			Duration:           &metav1.Duration{Duration: time.Hour},
+			Suspend:            true,
+			DeletionPolicy:     monitoringv1alpha1.DeletionPolicyOrphan,
+		},
+		Status: monitoringv1alpha1.SilenceStatus{
+			Active:                true,
+			AlertManagerID:        "id",
+			LastAppliedGeneration: 3,
+			Matchers:              `{alertname="DatabaseDown"}`,
+			AppliedMatchers: monitoringv1alpha1.Matchers{
+				{Name: "alertname", Value: "DatabaseDown", MatchType: monitoringv1alpha1.MatchEqual},
+			},
+			FromAlert: &monitoringv1alpha1.ResolvedAlert{
+				Reference:   monitoringv1alpha1.AlertReference{Fingerprint: "abc"},
+				Fingerprint: "abc",
+				Matchers:    monitoringv1alpha1.Matchers{{Name: "instance", Value: "db-1", MatchType: monitoringv1alpha1.MatchEqual}},
+			},
+			MutedAlerts:       1,
+			MutedAlertSamples: []monitoringv1alpha1.MutedAlert{{Name: "DatabaseDown", Fingerprint: "abc"}},
+			Conditions: []metav1.Condition{
+				{Type: monitoringv1alpha1.ConditionMatchersValid, Status: metav1.ConditionTrue, Reason: "Valid"},
+			},
+		},
+	}
+
+	spoke := &Silence{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+
+	wantTypes := []MatchType{MatchEqual, MatchRegexp, MatchNotEqual, MatchNotRegexp}
+	for i, m := range spoke.Spec.Matchers {
+		if m.MatchType != wantTypes[i] {
+			t.Errorf("matcher %d: MatchType = %q, want %q", i, m.MatchType, wantTypes[i])
+		}
+	}
+
+	got := &monitoringv1alpha1.Silence{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(hub, got) {
+		t.Errorf("round trip changed the silence:\n got %+v\nwant %+v", got, hub)
+	}
+}
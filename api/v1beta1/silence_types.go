@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionPolicy decides what happens to the alertmanager silence when the Silence is deleted.
+// +kubebuilder:validation:Enum=Expire;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyExpire expires the alertmanager silence.
+	DeletionPolicyExpire DeletionPolicy = "Expire"
+
+	// DeletionPolicyOrphan leaves the alertmanager silence running until it ends on its own.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// SilenceSpec defines the desired state of Silence.
+// +kubebuilder:validation:XValidation:rule="(has(self.matchers) && size(self.matchers) > 0) || (has(self.matcherExpressions) && size(self.matcherExpressions) > 0) || has(self.templateRef) || has(self.fromAlert)",message="at least one of matchers, matcherExpressions, templateRef or fromAlert is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.matchers) || size(self.matchers) == 0 || has(self.matcherExpressions) || has(self.templateRef) || has(self.fromAlert) || self.matchers.exists(m, !has(m.matchType) || m.matchType in ['=', '=~'])",message="at least one matcher must be a positive = or =~ match"
+type SilenceSpec struct {
+	Comment string `json:"comment"`
+
+	// +optional
+	Matchers Matchers `json:"matchers,omitempty"`
+
+	// MatcherExpressions are matchers in the alertmanager syntax, e.g. `severity=~"warning|info"`.
+	// They are combined with the other matchers.
+	// +optional
+	MatcherExpressions []string `json:"matcherExpressions,omitempty"`
+
+	// TemplateRef renders additional matchers from a SilenceTemplate in the same namespace.
+	// +optional
+	TemplateRef *TemplateReference `json:"templateRef,omitempty"`
+
+	// FromAlert derives equality matchers from the labels of a currently firing alert.
+	// The alert is looked up once, the derived matchers are kept in the status after it resolves.
+	// +optional
+	FromAlert *AlertReference `json:"fromAlert,omitempty"`
+
+	// Duration is the length of the rolling alertmanager silence window.
+	// The silence is extended before it ends, so it is kept active while the object exists.
+	// Defaults to the operator's --silence-duration.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// MaxLifetime limits how long after the object creation the silence is kept active.
+	// Once reached, the silence is not extended anymore and the Expired condition is set.
+	// +optional
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+
+	// EndsAt is the moment after which the silence is not extended anymore.
+	// Once reached, the Expired condition is set.
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+
+	// Suspend temporarily disables the silence: the alertmanager silence is expired
+	// and created again once suspend is unset.
+	// +kubebuilder:default:=false
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DeletionPolicy decides whether the alertmanager silence is expired or left running
+	// when the object is deleted.
+	// +kubebuilder:default:=Expire
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DryRun previews the silence: the matchers are evaluated against the current alerts
+	// and the alerts which would be silenced are reported in the status, but nothing is posted to alertmanager.
+	// An alertmanager silence applied before is not extended anymore.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// TemplateReference references a SilenceTemplate and provides values for its placeholders.
+type TemplateReference struct {
+	// Name of the SilenceTemplate in the same namespace.
+	Name string `json:"name"`
+
+	// Parameters are available in the template matchers as {{ .<parameter> }}.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AlertReference identifies a firing alert either by its fingerprint or by its name and a subset of its labels.
+// +kubebuilder:validation:XValidation:rule="has(self.fingerprint) || has(self.alertName)",message="either fingerprint or alertName is required"
+type AlertReference struct {
+	// Fingerprint of the alert as reported by alertmanager.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// AlertName is the alertname label of the alert.
+	// +optional
+	AlertName string `json:"alertName,omitempty"`
+
+	// Labels the alert must have in addition to the alertname.
+	// They must identify a single alert.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// IncludeLabels limits the derived matchers to the given label names.
+	// All labels of the alert are used if empty.
+	// +optional
+	IncludeLabels []string `json:"includeLabels,omitempty"`
+
+	// ExcludeLabels are never turned into matchers.
+	// +optional
+	ExcludeLabels []string `json:"excludeLabels,omitempty"`
+}
+
+// ResolvedAlert records the alert found for spec.fromAlert and the matchers derived from it.
+type ResolvedAlert struct {
+	// Reference is the spec.fromAlert the alert was looked up for.
+	Reference   AlertReference `json:"reference"`
+	Fingerprint string         `json:"fingerprint"`
+	Matchers    Matchers       `json:"matchers"`
+}
+
+// MutedAlert identifies an alert muted by the silence.
+type MutedAlert struct {
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// SilenceStatus defines the observed state of Silence.
+type SilenceStatus struct {
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// AlertManagerID is the ID of the alertmanager silence.
+	// +optional
+	AlertManagerID string `json:"alertmanagerID,omitempty"`
+
+	// LastAppliedGeneration is the generation of the spec last applied to alertmanager.
+	// +optional
+	LastAppliedGeneration int64 `json:"lastAppliedGeneration,omitempty"`
+
+	// Matchers summarizes the applied matchers in the alertmanager syntax.
+	// +optional
+	Matchers string `json:"matchers,omitempty"`
+
+	// AppliedMatchers are the matchers of the alertmanager silence, including the rendered ones.
+	// +optional
+	AppliedMatchers Matchers `json:"appliedMatchers,omitempty"`
+
+	// FromAlert is set once the alert referenced by spec.fromAlert was found.
+	// +optional
+	FromAlert *ResolvedAlert `json:"fromAlert,omitempty"`
+
+	// MutedAlerts is the number of alerts muted by the alertmanager silence at the last check.
+	// +optional
+	MutedAlerts int `json:"mutedAlerts,omitempty"`
+
+	// MutedAlertSamples lists some of the muted alerts.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MutedAlertSamples []MutedAlert `json:"mutedAlertSamples,omitempty"`
+
+	// MatchingNothingSince is when the silence stopped muting alerts, unset while it mutes any.
+	// +optional
+	MatchingNothingSince *metav1.Time `json:"matchingNothingSince,omitempty"`
+
+	// MatchingAlerts is the number of alerts the silence would mute, reported in dry run.
+	// +optional
+	MatchingAlerts int `json:"matchingAlerts,omitempty"`
+
+	// MatchingAlertSamples lists some of the alerts the silence would mute, reported in dry run.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	MatchingAlertSamples []MutedAlert `json:"matchingAlertSamples,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="AlertmanagerID",type=string,JSONPath=`.status.alertmanagerID`
+// +kubebuilder:printcolumn:name="EndsAt",type=date,JSONPath=`.spec.endsAt`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Matchers",type=string,JSONPath=`.status.matchers`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Silence is the Schema for the silences API.
+type Silence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SilenceSpec   `json:"spec,omitempty"`
+	Status SilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SilenceList contains a list of Silence.
+type SilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Silence `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Silence{}, &SilenceList{})
+}
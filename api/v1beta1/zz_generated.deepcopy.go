@@ -0,0 +1,304 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertReference) DeepCopyInto(out *AlertReference) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IncludeLabels != nil {
+		in, out := &in.IncludeLabels, &out.IncludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLabels != nil {
+		in, out := &in.ExcludeLabels, &out.ExcludeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertReference.
+func (in *AlertReference) DeepCopy() *AlertReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Matcher) DeepCopyInto(out *Matcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matcher.
+func (in *Matcher) DeepCopy() *Matcher {
+	if in == nil {
+		return nil
+	}
+	out := new(Matcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Matchers) DeepCopyInto(out *Matchers) {
+	{
+		in := &in
+		*out = make(Matchers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matchers.
+func (in Matchers) DeepCopy() Matchers {
+	if in == nil {
+		return nil
+	}
+	out := new(Matchers)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutedAlert) DeepCopyInto(out *MutedAlert) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutedAlert.
+func (in *MutedAlert) DeepCopy() *MutedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(MutedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedAlert) DeepCopyInto(out *ResolvedAlert) {
+	*out = *in
+	in.Reference.DeepCopyInto(&out.Reference)
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make(Matchers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedAlert.
+func (in *ResolvedAlert) DeepCopy() *ResolvedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Silence) DeepCopyInto(out *Silence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Silence.
+func (in *Silence) DeepCopy() *Silence {
+	if in == nil {
+		return nil
+	}
+	out := new(Silence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Silence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceList) DeepCopyInto(out *SilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Silence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceList.
+func (in *SilenceList) DeepCopy() *SilenceList {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceSpec) DeepCopyInto(out *SilenceSpec) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make(Matchers, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatcherExpressions != nil {
+		in, out := &in.MatcherExpressions, &out.MatcherExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromAlert != nil {
+		in, out := &in.FromAlert, &out.FromAlert
+		*out = new(AlertReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceSpec.
+func (in *SilenceSpec) DeepCopy() *SilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceStatus) DeepCopyInto(out *SilenceStatus) {
+	*out = *in
+	if in.AppliedMatchers != nil {
+		in, out := &in.AppliedMatchers, &out.AppliedMatchers
+		*out = make(Matchers, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromAlert != nil {
+		in, out := &in.FromAlert, &out.FromAlert
+		*out = new(ResolvedAlert)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MutedAlertSamples != nil {
+		in, out := &in.MutedAlertSamples, &out.MutedAlertSamples
+		*out = make([]MutedAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchingNothingSince != nil {
+		in, out := &in.MatchingNothingSince, &out.MatchingNothingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.MatchingAlertSamples != nil {
+		in, out := &in.MatchingAlertSamples, &out.MatchingAlertSamples
+		*out = make([]MutedAlert, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceStatus.
+func (in *SilenceStatus) DeepCopy() *SilenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateReference) DeepCopyInto(out *TemplateReference) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateReference.
+func (in *TemplateReference) DeepCopy() *TemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func newCreateCommand(o *options) *cobra.Command {
+	var name, comment string
+	var duration time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "create MATCHER...",
+		Short: "Create a silence for the alerts matching all matchers",
+		Example: `  kubectl silence create --for 2h --comment "Database maintenance" alertname=DatabaseDown namespace=db
+  kubectl silence create --comment "Noisy until fixed" 'severity=~"info|warning"' team=payments`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			s, err := newSilence(namespace, name, comment, duration, args, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if err := c.Create(cmd.Context(), s); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "silence/%s created\n", s.Name)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the Silence, generated if empty.")
+	cmd.Flags().StringVar(&comment, "comment", "", "Comment of the alertmanager silence.")
+	cmd.Flags().DurationVar(&duration, "for", 0,
+		"How long the silence stays active. Without it, the silence is active until it is deleted.")
+	_ = cmd.MarkFlagRequired("comment")
+
+	return cmd
+}
+
+// newSilence builds the Silence for the given matchers in the alertmanager syntax, e.g. `severity=~"info|warning"`.
+// A positive duration sets endsAt relative to now.
+func newSilence(
+	namespace, name, comment string, duration time.Duration, expressions []string, now time.Time,
+) (*monitoringv1alpha1.Silence, error) {
+	matchers := monitoringv1alpha1.Matchers{}
+
+	for _, expression := range expressions {
+		m, err := monitoringv1alpha1.ParseMatcher(expression)
+		if err != nil {
+			return nil, fmt.Errorf("matcher %q: %w", expression, err)
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	if err := matchers.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &monitoringv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: monitoringv1alpha1.SilenceSpec{
+			Comment:  comment,
+			Matchers: matchers,
+		},
+	}
+
+	if name == "" {
+		s.GenerateName = "silence-"
+	}
+
+	if duration > 0 {
+		s.Spec.EndsAt = &metav1.Time{Time: now.Add(duration)}
+	}
+
+	return s, nil
+}
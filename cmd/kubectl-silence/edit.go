@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func newExtendCommand(o *options) *cobra.Command {
+	var by time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "extend NAME...",
+		Short: "Move the end of silences",
+		Long: "Move the endsAt of silences by the given duration. An endsAt in the past is moved from now, " +
+			"so an expired silence becomes active again.",
+		Example: "  kubectl silence extend --by 1h database-maintenance",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+
+			return o.patchSilences(cmd, args, "extended", func(s *monitoringv1alpha1.Silence) error {
+				t, err := extendedEndsAt(s, by, now)
+				if err != nil {
+					return err
+				}
+
+				s.Spec.EndsAt = &metav1.Time{Time: t}
+
+				if expiresAt := s.ExpiresAt(); expiresAt.Before(t) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: silence/%s still ends at %s because of its maxLifetime\n",
+						s.Name, expiresAt.Format(time.RFC3339))
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&by, "by", 0, "The duration to extend the silences by.")
+	_ = cmd.MarkFlagRequired("by")
+
+	return cmd
+}
+
+// extendedEndsAt returns the endsAt of the silence moved by the given duration. Silences without endsAt
+// are active while they exist and can't be extended.
+func extendedEndsAt(s *monitoringv1alpha1.Silence, by time.Duration, now time.Time) (time.Time, error) {
+	if by <= 0 {
+		return time.Time{}, errors.New("the duration to extend by must be positive")
+	}
+
+	if s.Spec.EndsAt == nil {
+		return time.Time{}, fmt.Errorf("silence/%s has no endsAt, it is active while it exists", s.Name)
+	}
+
+	from := s.Spec.EndsAt.Time
+	if from.Before(now) {
+		from = now
+	}
+
+	return from.Add(by), nil
+}
+
+// newSuspendCommand returns the suspend command, or the resume command if suspend is false.
+func newSuspendCommand(o *options, suspend bool) *cobra.Command {
+	use, short, done := "suspend", "Expire silences in alertmanager until they are resumed", "suspended"
+	if !suspend {
+		use, short, done = "resume", "Apply suspended silences to alertmanager again", "resumed"
+	}
+
+	return &cobra.Command{
+		Use:   use + " NAME...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.patchSilences(cmd, args, done, func(s *monitoringv1alpha1.Silence) error {
+				s.Spec.Suspend = suspend
+
+				return nil
+			})
+		},
+	}
+}
+
+func newDeleteCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME...",
+		Short: "Delete silences, which expires them in alertmanager unless their deletion policy is Orphan",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			for _, name := range args {
+				s := &monitoringv1alpha1.Silence{}
+				s.Namespace, s.Name = namespace, name
+
+				if err := c.Delete(cmd.Context(), s); err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "silence/%s deleted\n", name)
+			}
+
+			return nil
+		},
+	}
+}
+
+// patchSilences applies change to each of the named silences with a merge patch, so only the changed fields are sent.
+func (o *options) patchSilences(
+	cmd *cobra.Command, names []string, done string, change func(*monitoringv1alpha1.Silence) error,
+) error {
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		s := &monitoringv1alpha1.Silence{}
+		if err := c.Get(cmd.Context(), client.ObjectKey{Namespace: namespace, Name: name}, s); err != nil {
+			return err
+		}
+
+		patch := client.MergeFrom(s.DeepCopy())
+
+		if err := change(s); err != nil {
+			return err
+		}
+
+		if err := c.Patch(cmd.Context(), s, patch); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "silence/%s %s\n", name, done)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+func newExportCommand(o *options) *cobra.Command {
+	var allNamespaces bool
+	var format, author, instanceName string
+	var duration time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the active silences as alertmanager silences",
+		Long: "Export the active Silence objects as a JSON array of alertmanager silences, " +
+			"e.g. to restore them into a rebuilt alertmanager without the operator running. " +
+			"The json format leaves out the silence IDs, so the silences can be posted to any alertmanager. " +
+			"The amtool format is the input of `amtool silence import` and keeps the IDs.",
+		Example: `  kubectl silence export -A --format amtool > silences.json
+  amtool silence import --alertmanager.url http://localhost:9093 silences.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			opts := []client.ListOption{}
+			if !allNamespaces {
+				opts = append(opts, client.InNamespace(namespace))
+			}
+
+			list := &monitoringv1alpha1.SilenceList{}
+			if err := c.List(cmd.Context(), list, opts...); err != nil {
+				return err
+			}
+
+			am, err := alertmanager.New(&alertmanager.Config{
+				Author:          author,
+				InstanceName:    instanceName,
+				SilenceDuration: duration,
+			})
+			if err != nil {
+				return err
+			}
+
+			silences, err := am.Export(list.Items, format, time.Now())
+			if err != nil {
+				return err
+			}
+
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+
+			return enc.Encode(silences)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Export the silences of all namespaces.")
+	cmd.Flags().StringVar(&format, "format", alertmanager.ExportFormatJSON,
+		"The output format, json or amtool.")
+	cmd.Flags().StringVar(&author, "silence-author", "silence-operator", "Author of the exported silences.")
+	cmd.Flags().StringVar(&instanceName, "instance-name", "silence-operator",
+		"Name of the operator instance, added to the comments.")
+	cmd.Flags().DurationVar(&duration, "silence-duration", time.Hour,
+		"How long silences without endsAt last. They are not extended without the operator running.")
+
+	return cmd
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+func newImportCommand(o *options) *cobra.Command {
+	var alertManagerURL, author, excludeAuthor, comment string
+	var create bool
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import existing alertmanager silences as Silence objects",
+		Long: "Import the active and pending silences of alertmanager as Silence objects. " +
+			"The Silences adopt the existing alertmanager silences instead of creating new ones. " +
+			"By default the manifests are written to stdout, so they can be committed to Git.",
+		Example: `  kubectl silence import --alertmanager-url http://localhost:9093 --author 'alice|bob' > silences.yaml
+  kubectl silence import --alertmanager-url http://localhost:9093 --comment maintenance --create`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := newImportFilter(author, excludeAuthor, comment)
+			if err != nil {
+				return err
+			}
+
+			am, err := alertmanager.New(&alertmanager.Config{URL: alertManagerURL})
+			if err != nil {
+				return err
+			}
+
+			result, err := am.GetSilences(nil)
+			if err != nil {
+				return err
+			}
+
+			// The manifests are written without access to the cluster, in the namespace given by the flag if any
+			var c client.Client
+			namespace := o.namespace
+
+			if create {
+				if c, namespace, err = o.client(); err != nil {
+					return err
+				}
+			}
+
+			for _, s := range result.GetPayload() {
+				if !filter.matches(s) {
+					continue
+				}
+
+				obj := importedSilence(s, namespace)
+
+				if !create {
+					out, err := yaml.Marshal(obj)
+					if err != nil {
+						return err
+					}
+
+					fmt.Fprintf(cmd.OutOrStdout(), "---\n%s", out)
+
+					continue
+				}
+
+				created, err := createImported(cmd.Context(), c, obj)
+				if err != nil {
+					return err
+				}
+
+				if created {
+					fmt.Fprintf(cmd.OutOrStdout(), "silence/%s created\n", obj.Name)
+				} else {
+					fmt.Fprintf(cmd.ErrOrStderr(), "silence/%s already exists\n", obj.Name)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&alertManagerURL, "alertmanager-url", "", "AlertManager URL.")
+	cmd.Flags().StringVar(&author, "author", "", "Only import silences whose author matches the regular expression.")
+	cmd.Flags().StringVar(&excludeAuthor, "exclude-author", "silence-operator",
+		"Skip silences whose author matches the regular expression, by default the ones of the operator.")
+	cmd.Flags().StringVar(&comment, "comment", "", "Only import silences whose comment matches the regular expression.")
+	cmd.Flags().BoolVar(&create, "create", false, "Create the Silence objects instead of writing their manifests.")
+	_ = cmd.MarkFlagRequired("alertmanager-url")
+
+	return cmd
+}
+
+// importFilter selects the alertmanager silences to import. Empty expressions match everything.
+type importFilter struct {
+	author, excludeAuthor, comment *regexp.Regexp
+}
+
+func newImportFilter(author, excludeAuthor, comment string) (*importFilter, error) {
+	f := &importFilter{}
+
+	for _, e := range []struct {
+		re   **regexp.Regexp
+		expr string
+	}{{&f.author, author}, {&f.excludeAuthor, excludeAuthor}, {&f.comment, comment}} {
+		if e.expr == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(e.expr)
+		if err != nil {
+			return nil, err
+		}
+
+		*e.re = re
+	}
+
+	return f, nil
+}
+
+// matches reports whether the silence is still active or pending and matches the filter.
+func (f *importFilter) matches(s *models.GettableSilence) bool {
+	if s.ID == nil || s.Status == nil || s.Status.State == nil || *s.Status.State == models.SilenceStatusStateExpired {
+		return false
+	}
+
+	author, comment := deref(s.CreatedBy), deref(s.Comment)
+
+	return (f.author == nil || f.author.MatchString(author)) &&
+		(f.excludeAuthor == nil || !f.excludeAuthor.MatchString(author)) &&
+		(f.comment == nil || f.comment.MatchString(comment))
+}
+
+// createImported creates the imported Silence. It returns false if the Silence already exists and adopts the
+// same alertmanager silence, i.e. it was imported before, and fails if an existing Silence adopts another one.
+func createImported(ctx context.Context, c client.Client, obj *monitoringv1alpha1.Silence) (bool, error) {
+	err := c.Create(ctx, obj)
+	if !apierrors.IsAlreadyExists(err) {
+		return err == nil, err
+	}
+
+	existing := &monitoringv1alpha1.Silence{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return false, err
+	}
+
+	id := obj.Annotations[monitoringv1alpha1.AdoptSilenceAnnotation]
+	if adopted := existing.Annotations[monitoringv1alpha1.AdoptSilenceAnnotation]; adopted != id {
+		return false, fmt.Errorf("silence/%s already exists and doesn't adopt the alertmanager silence %s", obj.Name, id)
+	}
+
+	return false, nil
+}
+
+// importedSilence returns the Silence adopting the given alertmanager silence. It is named after the silence ID,
+// so importing the same silence again doesn't create a duplicate.
+func importedSilence(s *models.GettableSilence, namespace string) *monitoringv1alpha1.Silence {
+	id := *s.ID
+
+	obj := &monitoringv1alpha1.Silence{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1alpha1.GroupVersion.String(),
+			Kind:       monitoringv1alpha1.SilenceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "imported-" + strings.ToLower(id),
+			Annotations: map[string]string{
+				monitoringv1alpha1.AdoptSilenceAnnotation: id,
+			},
+		},
+		Spec: monitoringv1alpha1.SilenceSpec{
+			Comment:  deref(s.Comment),
+			Matchers: alertmanager.FromModels(s.Matchers),
+		},
+	}
+
+	if s.EndsAt != nil {
+		obj.Spec.EndsAt = &metav1.Time{Time: time.Time(*s.EndsAt)}
+	}
+
+	return obj
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
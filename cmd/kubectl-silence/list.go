@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func newListCommand(o *options) *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List silences with their state and alertmanager IDs",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, namespace, err := o.client()
+			if err != nil {
+				return err
+			}
+
+			opts := []client.ListOption{}
+			if !allNamespaces {
+				opts = append(opts, client.InNamespace(namespace))
+			}
+
+			list := &monitoringv1alpha1.SilenceList{}
+			if err := c.List(cmd.Context(), list, opts...); err != nil {
+				return err
+			}
+
+			slices.SortFunc(list.Items, func(a, b monitoringv1alpha1.Silence) int {
+				return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+			})
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+			now := time.Now()
+
+			if allNamespaces {
+				fmt.Fprint(w, "NAMESPACE\t")
+			}
+
+			fmt.Fprintln(w, "NAME\tSTATE\tALERTMANAGER ID\tENDS AT\tMATCHERS\tAGE")
+
+			for _, s := range list.Items {
+				if allNamespaces {
+					fmt.Fprintf(w, "%s\t", s.Namespace)
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					s.Name,
+					silenceState(&s),
+					valueOrDash(s.Status.AlertManagerID),
+					endsAt(&s),
+					matchers(&s),
+					duration.HumanDuration(now.Sub(s.CreationTimestamp.Time)),
+				)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the silences of all namespaces.")
+
+	return cmd
+}
+
+// silenceState resolves the status of a silence into a single word, the most relevant condition first.
+func silenceState(s *monitoringv1alpha1.Silence) string {
+	conditions := s.Status.Conditions
+
+	switch {
+	case !s.DeletionTimestamp.IsZero():
+		return "Deleting"
+	case meta.IsStatusConditionTrue(conditions, monitoringv1alpha1.ConditionSuspended):
+		return "Suspended"
+	case meta.IsStatusConditionTrue(conditions, monitoringv1alpha1.ConditionExpired):
+		return "Expired"
+	case meta.IsStatusConditionFalse(conditions, monitoringv1alpha1.ConditionMatchersValid):
+		return "InvalidMatchers"
+	case meta.IsStatusConditionFalse(conditions, monitoringv1alpha1.ConditionTemplateRendered):
+		return "TemplateFailed"
+	case meta.IsStatusConditionFalse(conditions, monitoringv1alpha1.ConditionAlertResolved):
+		return "AlertNotFound"
+	case meta.IsStatusConditionTrue(conditions, monitoringv1alpha1.ConditionDryRun):
+		return "DryRun"
+	case meta.IsStatusConditionTrue(conditions, monitoringv1alpha1.ConditionMatchesNothing):
+		return "MatchesNothing"
+	case s.Status.Active:
+		return "Active"
+	}
+
+	return "Pending"
+}
+
+// endsAt returns when the silence stops being extended, or "-" if it is kept active while it exists.
+func endsAt(s *monitoringv1alpha1.Silence) string {
+	if t := s.ExpiresAt(); t != nil {
+		return t.Format(time.RFC3339)
+	}
+
+	return "-"
+}
+
+// matchers returns the applied matchers, or the spec matchers of a silence which was not applied yet.
+func matchers(s *monitoringv1alpha1.Silence) string {
+	if s.Status.Matchers != "" {
+		return s.Status.Matchers
+	}
+
+	return "{" + strings.Join(s.Spec.Matchers.String(), ", ") + "}"
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
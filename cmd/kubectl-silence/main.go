@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-silence manages Silence objects from the command line. Installed in the PATH,
+// it is available as `kubectl silence`.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// fieldManager is the field manager of the changes made by the plugin.
+const fieldManager = "kubectl-silence"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1alpha1.AddToScheme(scheme))
+}
+
+// options are the flags shared by all commands.
+type options struct {
+	kubeconfig string
+	context    string
+	namespace  string
+}
+
+// client returns a client for the selected cluster together with the namespace to work in,
+// which defaults to the namespace of the kubeconfig context.
+func (o *options) client() (client.Client, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: o.context,
+		Context:        clientcmdapi.Context{Namespace: o.namespace},
+	})
+
+	namespace, _, err := config.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client.WithFieldOwner(c, fieldManager), namespace, nil
+}
+
+func newRootCommand() *cobra.Command {
+	o := &options{}
+
+	cmd := &cobra.Command{
+		Use:           "kubectl-silence",
+		Short:         "Manage alertmanager silences through Silence objects",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file.")
+	cmd.PersistentFlags().StringVar(&o.context, "context", "", "The kubeconfig context to use.")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the silences.")
+
+	cmd.AddCommand(
+		newCreateCommand(o),
+		newListCommand(o),
+		newExtendCommand(o),
+		newSuspendCommand(o, true),
+		newSuspendCommand(o, false),
+		newDeleteCommand(o),
+		newImportCommand(o),
+		newExportCommand(o),
+	)
+
+	return cmd
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestNewSilence(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := newSilence("monitoring", "", "maintenance", 2*time.Hour,
+		[]string{"alertname=Foo", `severity=~"info|warning"`}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.GenerateName != "silence-" || s.Namespace != "monitoring" {
+		t.Errorf("unexpected metadata %+v", s.ObjectMeta)
+	}
+
+	if got := s.Spec.Matchers.String(); len(got) != 2 || got[0] != `alertname="Foo"` || got[1] != `severity=~"info|warning"` {
+		t.Errorf("unexpected matchers %v", got)
+	}
+
+	if !s.Spec.EndsAt.Time.Equal(now.Add(2 * time.Hour)) {
+		t.Errorf("unexpected endsAt %v", s.Spec.EndsAt)
+	}
+
+	if _, err := newSilence("monitoring", "", "maintenance", 0, []string{"severity=~("}, now); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
+
+func TestExtendedEndsAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		endsAt *metav1.Time
+		want   time.Time
+		err    bool
+	}{
+		{name: "future", endsAt: &metav1.Time{Time: now.Add(time.Hour)}, want: now.Add(2 * time.Hour)},
+		{name: "past", endsAt: &metav1.Time{Time: now.Add(-time.Hour)}, want: now.Add(time.Hour)},
+		{name: "open-ended", err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &monitoringv1alpha1.Silence{Spec: monitoringv1alpha1.SilenceSpec{EndsAt: tc.endsAt}}
+
+			got, err := extendedEndsAt(s, time.Hour, now)
+			if (err != nil) != tc.err {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !tc.err && !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestImportedSilence(t *testing.T) {
+	id := "5b1e1a2c-7a6f-4c1e-9f3e-2d1c0b9a8f7e"
+	endsAt := strfmt.DateTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := &models.GettableSilence{
+		ID:     &id,
+		Status: &models.SilenceStatus{State: ptr.To(models.SilenceStatusStateActive)},
+		Silence: models.Silence{
+			Comment:   ptr.To("maintenance"),
+			CreatedBy: ptr.To("alice"),
+			EndsAt:    &endsAt,
+			Matchers: models.Matchers{
+				{Name: ptr.To("alertname"), Value: ptr.To("Foo"), IsEqual: ptr.To(true), IsRegex: ptr.To(false)},
+			},
+		},
+	}
+
+	filter, err := newImportFilter("alice|bob", "silence-operator", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !filter.matches(s) {
+		t.Error("expected the silence to match the filter")
+	}
+
+	obj := importedSilence(s, "monitoring")
+
+	if obj.Name != "imported-"+id || obj.Annotations[monitoringv1alpha1.AdoptSilenceAnnotation] != id {
+		t.Errorf("unexpected metadata %+v", obj.ObjectMeta)
+	}
+
+	if got := obj.Spec.Matchers.String(); len(got) != 1 || got[0] != `alertname="Foo"` {
+		t.Errorf("unexpected matchers %v", got)
+	}
+
+	s.Status.State = ptr.To(models.SilenceStatusStateExpired)
+
+	if filter.matches(s) {
+		t.Error("expected an expired silence to be skipped")
+	}
+}
+
+func TestCreateImported(t *testing.T) {
+	silence := func(id string) *models.GettableSilence {
+		return &models.GettableSilence{ID: &id, Silence: models.Silence{Comment: ptr.To("maintenance")}}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	obj := importedSilence(silence("5b1e1a2c-7a6f-4c1e-9f3e-2d1c0b9a8f7e"), "monitoring")
+
+	if created, err := createImported(context.Background(), c, obj.DeepCopy()); err != nil || !created {
+		t.Fatalf("createImported() = %v, %v, want the silence to be created", created, err)
+	}
+
+	if created, err := createImported(context.Background(), c, obj.DeepCopy()); err != nil || created {
+		t.Fatalf("createImported() = %v, %v, want the silence to be imported already", created, err)
+	}
+
+	other := obj.DeepCopy()
+	other.Annotations[monitoringv1alpha1.AdoptSilenceAnnotation] = "5b1e1a2c-0000-4c1e-9f3e-2d1c0b9a8f7e"
+
+	if _, err := createImported(context.Background(), c, other); err == nil {
+		t.Error("expected an error for an existing silence adopting another alertmanager silence")
+	}
+}
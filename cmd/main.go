@@ -29,12 +29,16 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -42,8 +46,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	monitoringv1beta1 "github.com/silence-operator/silence-operator/api/v1beta1"
 	"github.com/silence-operator/silence-operator/internal/alertmanager"
 	"github.com/silence-operator/silence-operator/internal/controller"
+	webhookmonitoringv1alpha1 "github.com/silence-operator/silence-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -60,12 +66,19 @@ const (
 	defaultConcurrency        = 10
 	defaultGetSilenceAttempts = 3
 	defaultGetSilenceInterval = time.Second * 10
+	defaultRefreshInterval    = time.Minute
+	defaultExtendThreshold    = 0.25
+	defaultRolloutGracePeriod = time.Minute * 5
+	defaultMatchesNothingTime = time.Hour
+	defaultDeletionTimeout    = time.Minute * 10
+	defaultMassLossThreshold  = 0.5
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(monitoringv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -86,6 +99,21 @@ func main() {
 	var silenceDuration time.Duration
 	var getSilenceAttempts int
 	var getSilenceInterval time.Duration
+	var refreshInterval time.Duration
+	var extendThreshold float64
+	var matchesNothingAfter time.Duration
+	var deletionTimeout time.Duration
+	var massLossThreshold float64
+	var dryRun bool
+	var enableRolloutSilences bool
+	var rolloutGracePeriod time.Duration
+	var enableNodeSilences bool
+	var enableSilencesExport bool
+	var nodeSilenceNamespace string
+	var nodeMaintenanceTaint string
+	var nodeMaintenanceAnnotation string
+	var nodeSilenceMatchers string
+	var enableNamespaceSilences bool
 	var concurrency int
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -109,13 +137,48 @@ func main() {
 	flag.StringVar(&silenceAuthor, "silence-author", defaultSilenceAuthor,
 		"This string will be used as 'Created by' field in AM silence.")
 	flag.StringVar(&alertManagerURL, "alertmanager-url", "", "AlertManager URL.")
-	flag.DurationVar(&interval, "interval", defaultInterval, "The interval between reconciliations.")
+	flag.DurationVar(&interval, "interval", defaultInterval, "The maximum interval between reconciliations.")
 	flag.DurationVar(&silenceDuration, "silence-duration", defaultDuration,
 		"The duration for the silence.")
+	flag.Float64Var(&extendThreshold, "extend-threshold", defaultExtendThreshold,
+		"The fraction of the silence duration left when the silence gets extended, between 0 and 1.")
 	flag.IntVar(&getSilenceAttempts, "get-silence-attempts", defaultGetSilenceAttempts,
 		"Number of attempts to get the silence.")
 	flag.DurationVar(&getSilenceInterval, "get-silence-interval", defaultGetSilenceInterval,
 		"The interval between get silence attempts.")
+	flag.DurationVar(&refreshInterval, "cache-refresh-interval", defaultRefreshInterval,
+		"The interval between refreshes of the cached alertmanager silences and alerts. Set to 0 to disable the cache.")
+	flag.DurationVar(&matchesNothingAfter, "matches-nothing-after", defaultMatchesNothingTime,
+		"How long a silence may mute no alert before its MatchesNothing condition is set. Set to 0 to disable.")
+	flag.Float64Var(&massLossThreshold, "mass-loss-threshold", defaultMassLossThreshold,
+		"The fraction of the unexpired managed silences which has to disappear between two cache refreshes "+
+			"to re-create all silences right away. Set to 0 to disable.")
+	flag.DurationVar(&deletionTimeout, "deletion-timeout", defaultDeletionTimeout,
+		"How long the alertmanager silences of a deleted Silence or SilenceGroup are retried to be expired "+
+			"before the finalizer gives up.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, silences are not applied to alertmanager, the alerts they would mute are reported in their status.")
+	flag.BoolVar(&enableRolloutSilences, "enable-rollout-silences", false,
+		"If set, Deployments, StatefulSets and DaemonSets annotated with "+
+			monitoringv1alpha1.RolloutMatchersAnnotation+" are silenced while their rollout is in progress.")
+	flag.DurationVar(&rolloutGracePeriod, "rollout-silence-grace-period", defaultRolloutGracePeriod,
+		"How long the rollout silence is kept after the rollout completed.")
+	flag.BoolVar(&enableNodeSilences, "enable-node-silences", false,
+		"If set, cordoned nodes and nodes carrying the maintenance taint or annotation are silenced.")
+	flag.BoolVar(&enableSilencesExport, "enable-silences-export", false,
+		"If set, the silences are exported as alertmanager silences at "+alertmanager.ExportPath+
+			" on the metrics server. Requires --metrics-secure.")
+	flag.StringVar(&nodeSilenceNamespace, "node-silence-namespace", "default",
+		"The namespace where node maintenance silences are created.")
+	flag.StringVar(&nodeMaintenanceTaint, "node-maintenance-taint", "",
+		"The taint key marking a node under maintenance.")
+	flag.StringVar(&nodeMaintenanceAnnotation, "node-maintenance-annotation", "",
+		"The annotation marking a node under maintenance.")
+	flag.StringVar(&nodeSilenceMatchers, "node-silence-matchers", controller.DefaultNodeMatchersTemplate,
+		"Go template rendering a JSON list of matcher lists, one silence is created per matcher list. "+
+			"Available fields: .Name, .InternalIP and .Labels.")
+	flag.BoolVar(&enableNamespaceSilences, "enable-namespace-silences", false,
+		"If set, namespaces annotated with "+monitoringv1alpha1.NamespaceSilenceUntilAnnotation+" are silenced.")
 	flag.IntVar(&concurrency, "concurrency", defaultConcurrency,
 		"Amount of silences to be processed in parallel.")
 
@@ -172,6 +235,17 @@ func main() {
 		TLSOpts: webhookTLSOpts,
 	})
 
+	if extendThreshold <= 0 || extendThreshold >= 1 {
+		setupLog.Error(errors.New("--extend-threshold must be between 0 and 1"), "Failed to start controller.",
+			"extend-threshold", extendThreshold)
+		os.Exit(1)
+	}
+
+	if enableSilencesExport && !secureMetrics {
+		setupLog.Error(errors.New("--enable-silences-export requires --metrics-secure"), "Failed to start controller.")
+		os.Exit(1)
+	}
+
 	// Initialise alertmanager client
 	if alertManagerURL == "" {
 		setupLog.Error(errors.New("alertmanager url is empty"), "Failed to start controller.")
@@ -179,10 +253,12 @@ func main() {
 	}
 
 	alertManagerClient, err := alertmanager.New(&alertmanager.Config{
-		URL:             alertManagerURL,
-		Author:          silenceAuthor,
-		InstanceName:    instanceName,
-		SilenceDuration: silenceDuration,
+		URL:               alertManagerURL,
+		Author:            silenceAuthor,
+		InstanceName:      instanceName,
+		SilenceDuration:   silenceDuration,
+		RefreshInterval:   refreshInterval,
+		MassLossThreshold: massLossThreshold,
 	})
 	if err != nil {
 		setupLog.Error(errors.New("invalid alertmanager configuration"), "Failed to start controller.", "error", err)
@@ -262,19 +338,125 @@ func main() {
 		os.Exit(1)
 	}
 
+	// All writes of the operator are attributed to one field manager, so they show up
+	// as such in the managed fields next to the ones of GitOps tools.
+	managerClient := client.WithFieldOwner(mgr.GetClient(), controller.FieldManager)
+
+	// Objects to reconcile right away after alertmanager lost most silences
+	recoverSilences := make(chan event.GenericEvent)
+	recoverSilenceGroups := make(chan event.GenericEvent)
+
 	if err = (&controller.SilenceReconciler{
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
-		AlertManager:       alertManagerClient,
-		Interval:           interval,
-		GetSilenceAttempts: getSilenceAttempts,
-		GetSilenceInterval: getSilenceInterval,
+		Client:              managerClient,
+		Scheme:              mgr.GetScheme(),
+		AlertManager:        alertManagerClient,
+		Interval:            interval,
+		ExtendThreshold:     extendThreshold,
+		GetSilenceAttempts:  getSilenceAttempts,
+		GetSilenceInterval:  getSilenceInterval,
+		MatchesNothingAfter: matchesNothingAfter,
+		DeletionTimeout:     deletionTimeout,
+		DryRun:              dryRun,
+		Recorder:            mgr.GetEventRecorderFor("silence-controller"),
+		Recover:             recoverSilences,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Silence")
 		os.Exit(1)
 	}
+
+	if err = (&controller.SilenceGroupReconciler{
+		Client:          managerClient,
+		Scheme:          mgr.GetScheme(),
+		AlertManager:    alertManagerClient,
+		Interval:        interval,
+		ExtendThreshold: extendThreshold,
+		DryRun:          dryRun,
+		DeletionTimeout: deletionTimeout,
+		Recorder:        mgr.GetEventRecorderFor("silencegroup-controller"),
+		Recover:         recoverSilenceGroups,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SilenceGroup")
+		os.Exit(1)
+	}
+
+	if enableRolloutSilences {
+		for _, workload := range []client.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}, &appsv1.DaemonSet{}} {
+			if err = (&controller.RolloutReconciler{
+				Client:      managerClient,
+				Scheme:      mgr.GetScheme(),
+				Object:      workload,
+				GracePeriod: rolloutGracePeriod,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "Rollout")
+				os.Exit(1)
+			}
+		}
+	}
+
+	if enableNodeSilences {
+		matchersTemplate, err := controller.ParseNodeMatchersTemplate(nodeSilenceMatchers)
+		if err != nil {
+			setupLog.Error(err, "invalid node silence matchers template")
+			os.Exit(1)
+		}
+
+		if err = (&controller.NodeReconciler{
+			Client:                managerClient,
+			Scheme:                mgr.GetScheme(),
+			Namespace:             nodeSilenceNamespace,
+			MaintenanceTaint:      nodeMaintenanceTaint,
+			MaintenanceAnnotation: nodeMaintenanceAnnotation,
+			MatchersTemplate:      matchersTemplate,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Node")
+			os.Exit(1)
+		}
+	}
+
+	if enableNamespaceSilences {
+		if err = (&controller.NamespaceReconciler{
+			Client: managerClient,
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Namespace")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookmonitoringv1alpha1.SetupSilenceWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Silence")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	alertManagerClient.TrackedIDs = controller.TrackedIDs(mgr.GetClient())
+	if err := mgr.Add(alertManagerClient); err != nil {
+		setupLog.Error(err, "unable to add alertmanager silences cache to manager")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controller.MassLossRecovery{
+		Client:        mgr.GetClient(),
+		AlertManager:  alertManagerClient,
+		Recorder:      mgr.GetEventRecorderFor("silence-controller"),
+		Pod:           types.NamespacedName{Namespace: os.Getenv("POD_NAMESPACE"), Name: os.Getenv("POD_NAME")},
+		Silences:      recoverSilences,
+		SilenceGroups: recoverSilenceGroups,
+	}); err != nil {
+		setupLog.Error(err, "unable to add mass loss recovery to manager")
+		os.Exit(1)
+	}
+
+	if enableSilencesExport {
+		exportHandler := alertmanager.ExportHandler(mgr.GetClient(), alertManagerClient)
+		if err := mgr.AddMetricsServerExtraHandler(alertmanager.ExportPath, exportHandler); err != nil {
+			setupLog.Error(err, "unable to add silences export handler to metrics server")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
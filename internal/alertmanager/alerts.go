@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// ErrAlertNotFound is returned by FindAlert if no alert matches the reference.
+var ErrAlertNotFound = errors.New("alert not found")
+
+// FindAlert returns the alert referenced by fingerprint or, without fingerprint,
+// the single alert with the given alertname and labels.
+func (c *AlertManager) FindAlert(ref *v1alpha1.AlertReference) (*models.GettableAlert, error) {
+	filter := []string{}
+
+	if ref.Fingerprint == "" {
+		filter = append(filter, fmt.Sprintf("alertname=%q", ref.AlertName))
+
+		for _, name := range slices.Sorted(maps.Keys(ref.Labels)) {
+			filter = append(filter, fmt.Sprintf("%s=%q", name, ref.Labels[name]))
+		}
+	}
+
+	result, err := c.am.Alert.GetAlerts(&alert.GetAlertsParams{
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := models.GettableAlerts{}
+
+	for _, a := range result.GetPayload() {
+		if ref.Fingerprint == "" || (a.Fingerprint != nil && *a.Fingerprint == ref.Fingerprint) {
+			found = append(found, a)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, ErrAlertNotFound
+	case 1:
+		return found[0], nil
+	}
+
+	return nil, fmt.Errorf("%d alerts match, add labels or use the fingerprint to select a single one", len(found))
+}
+
+// AlertMatchers returns equality matchers for the labels of an alert,
+// limited by the include and exclude lists of the reference.
+func AlertMatchers(labels models.LabelSet, ref *v1alpha1.AlertReference) v1alpha1.Matchers {
+	matchers := v1alpha1.Matchers{}
+
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		if len(ref.IncludeLabels) > 0 && !slices.Contains(ref.IncludeLabels, name) {
+			continue
+		}
+
+		if slices.Contains(ref.ExcludeLabels, name) {
+			continue
+		}
+
+		matchers = append(matchers, v1alpha1.Matcher{
+			Name:      name,
+			Value:     labels[name],
+			MatchType: v1alpha1.MatchEqual,
+		})
+	}
+
+	return matchers
+}
+
+// getAlerts returns the alerts from the cached snapshot. Without snapshot, the alerts matching
+// the filter are queried from alertmanager.
+func (c *AlertManager) getAlerts(filter []string) (models.GettableAlerts, error) {
+	if alerts, ok := c.cache.getAlerts(); ok {
+		return alerts, nil
+	}
+
+	result, err := c.am.Alert.GetAlerts(&alert.GetAlertsParams{
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.GetPayload(), nil
+}
+
+// MutedAlerts returns the alerts currently silenced by the given alertmanager silence.
+// Without snapshot, the matchers of the silence narrow down the alerts returned by alertmanager.
+func (c *AlertManager) MutedAlerts(id string, matchers v1alpha1.Matchers) (models.GettableAlerts, error) {
+	alerts, err := c.getAlerts(matchers.String())
+	if err != nil {
+		return nil, err
+	}
+
+	muted := models.GettableAlerts{}
+
+	for _, a := range alerts {
+		if a.Status != nil && slices.Contains(a.Status.SilencedBy, id) {
+			muted = append(muted, a)
+		}
+	}
+
+	return muted, nil
+}
+
+// MatchingAlerts returns the alerts the given matchers would silence.
+// The matchers are evaluated locally, nothing is changed in alertmanager.
+func (c *AlertManager) MatchingAlerts(matchers v1alpha1.Matchers) (models.GettableAlerts, error) {
+	labelMatchers, err := toLabelMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := c.getAlerts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := models.GettableAlerts{}
+
+	for _, a := range alerts {
+		lset := model.LabelSet{}
+		for name, value := range a.Labels {
+			lset[model.LabelName(name)] = model.LabelValue(value)
+		}
+
+		if labelMatchers.Matches(lset) {
+			matching = append(matching, a)
+		}
+	}
+
+	return matching, nil
+}
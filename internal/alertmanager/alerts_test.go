@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"k8s.io/utils/ptr"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestAlertMatchers(t *testing.T) {
+	labels := models.LabelSet{
+		"alertname": "HighLatency",
+		"instance":  "10.0.0.1:9090",
+		"severity":  "critical",
+	}
+
+	tests := []struct {
+		name string
+		ref  v1alpha1.AlertReference
+		want []string
+	}{
+		{
+			name: "all labels",
+			ref:  v1alpha1.AlertReference{AlertName: "HighLatency"},
+			want: []string{`alertname="HighLatency"`, `instance="10.0.0.1:9090"`, `severity="critical"`},
+		},
+		{
+			name: "include labels",
+			ref:  v1alpha1.AlertReference{AlertName: "HighLatency", IncludeLabels: []string{"alertname", "instance"}},
+			want: []string{`alertname="HighLatency"`, `instance="10.0.0.1:9090"`},
+		},
+		{
+			name: "exclude labels",
+			ref:  v1alpha1.AlertReference{AlertName: "HighLatency", ExcludeLabels: []string{"severity"}},
+			want: []string{`alertname="HighLatency"`, `instance="10.0.0.1:9090"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AlertMatchers(labels, &tt.ref).String()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AlertMatchers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMutedAlertsFromSnapshot(t *testing.T) {
+	c := &AlertManager{cache: newSilenceCache(0)}
+	c.cache.replaceAlerts(models.GettableAlerts{
+		{Fingerprint: ptr.To("a"), Status: &models.AlertStatus{SilencedBy: []string{"s1"}}},
+		{Fingerprint: ptr.To("b"), Status: &models.AlertStatus{SilencedBy: []string{"s1", "s2"}}},
+		{Fingerprint: ptr.To("c"), Status: &models.AlertStatus{}},
+	})
+
+	// Without alertmanager client, the alerts can only come from the snapshot
+	muted, err := c.MutedAlerts("s2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(muted) != 1 || *muted[0].Fingerprint != "b" {
+		t.Errorf("expected only alert b to be muted by s2, got %d alerts", len(muted))
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// silenceCache keeps a snapshot of all alertmanager silences and alerts, so reconcilers
+// don't have to query alertmanager for every Silence object on every interval.
+type silenceCache struct {
+	mu sync.RWMutex
+
+	// maxAge is the age after which a snapshot is stale, e.g. because alertmanager can't be reached.
+	// Stale snapshots aren't used, so reads go to alertmanager. Zero keeps snapshots forever.
+	maxAge time.Duration
+
+	// refreshed is the time of the last snapshot of the silences, zero if they were never synced
+	refreshed time.Time
+	silences  map[string]*models.GettableSilence
+
+	// lost are the silences of the last mass loss
+	lost map[string]struct{}
+
+	alertsRefreshed time.Time
+	alerts          models.GettableAlerts
+}
+
+func newSilenceCache(maxAge time.Duration) *silenceCache {
+	return &silenceCache{
+		maxAge:   maxAge,
+		silences: map[string]*models.GettableSilence{},
+		lost:     map[string]struct{}{},
+	}
+}
+
+// fresh reports whether a snapshot taken at the given time can be used. Callers hold the lock.
+func (c *silenceCache) fresh(refreshed time.Time) bool {
+	return !refreshed.IsZero() && (c.maxAge == 0 || time.Since(refreshed) <= c.maxAge)
+}
+
+// replace swaps the whole snapshot with the given list of silences. It returns the IDs of the silences
+// which were not expired in the previous snapshot and are missing now, together with the IDs of all
+// silences which were not expired. Alertmanager keeps expired silences for a retention period, so a
+// missing unexpired silence means alertmanager lost it.
+func (c *silenceCache) replace(silences models.GettableSilences) ([]string, []string) {
+	snapshot := make(map[string]*models.GettableSilence, len(silences))
+
+	for _, s := range silences {
+		if s == nil || s.ID == nil {
+			continue
+		}
+
+		snapshot[*s.ID] = s
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lost := []string{}
+	unexpired := []string{}
+
+	if !c.refreshed.IsZero() {
+		for id, s := range c.silences {
+			if s.Status == nil || s.Status.State == nil || *s.Status.State == models.SilenceStatusStateExpired {
+				continue
+			}
+
+			unexpired = append(unexpired, id)
+
+			if _, ok := snapshot[id]; !ok {
+				lost = append(lost, id)
+			}
+		}
+	}
+
+	c.silences = snapshot
+	c.refreshed = time.Now()
+
+	return lost, unexpired
+}
+
+// replaceAlerts swaps the snapshot of the alerts.
+func (c *silenceCache) replaceAlerts(alerts models.GettableAlerts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.alerts = alerts
+	c.alertsRefreshed = time.Now()
+}
+
+// getAlerts returns the cached alerts. The second value is false if the alerts were never synced or are stale.
+func (c *silenceCache) getAlerts() (models.GettableAlerts, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.fresh(c.alertsRefreshed) {
+		return nil, false
+	}
+
+	return c.alerts, true
+}
+
+// get returns the cached silence. The second value is false if the cache was
+// never synced, is stale or the silence is not in the snapshot.
+func (c *silenceCache) get(id string) (*models.GettableSilence, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.fresh(c.refreshed) {
+		return nil, false
+	}
+
+	s, ok := c.silences[id]
+
+	return s, ok
+}
+
+// set stores a single silence, e.g. after it was fetched from alertmanager directly. Nothing is stored
+// without a fresh snapshot, e.g. when the cache is disabled, as no refresh would ever reset the map.
+func (c *silenceCache) set(s *models.GettableSilence) {
+	if s == nil || s.ID == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fresh(c.refreshed) {
+		return
+	}
+
+	c.silences[*s.ID] = s
+}
+
+// setLost replaces the silences known to be lost.
+func (c *silenceCache) setLost(ids []string) {
+	lost := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		lost[id] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lost = lost
+}
+
+// retainLost forgets the lost silences which are not tracked anymore, i.e. which were re-created.
+func (c *silenceCache) retainLost(tracked []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id := range c.lost {
+		if !slices.Contains(tracked, id) {
+			delete(c.lost, id)
+		}
+	}
+}
+
+// hasLost reports whether any silence of the last mass loss is still tracked.
+func (c *silenceCache) hasLost() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.lost) > 0
+}
+
+// isLost reports whether the silence was lost in the last mass loss.
+func (c *silenceCache) isLost(id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.lost[id]
+
+	return ok
+}
+
+// invalidate drops a single silence from the snapshot, so the next read goes to alertmanager.
+func (c *silenceCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.silences, id)
+}
+
+// find returns the cached silences matching the given matchers using the same
+// rules as the alertmanager filter query. The second value is false if the
+// cache was never synced or is stale.
+func (c *silenceCache) find(matchers v1alpha1.Matchers) (models.GettableSilences, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.fresh(c.refreshed) {
+		return nil, false
+	}
+
+	out := models.GettableSilences{}
+
+	for _, s := range c.silences {
+		if silenceMatchesFilter(s, matchers) {
+			out = append(out, s)
+		}
+	}
+
+	return out, true
+}
+
+// silenceMatchesFilter mirrors alertmanager's CheckSilenceMatchesFilterLabels:
+// every filter matcher must be present in the silence with the same type and value.
+func silenceMatchesFilter(s *models.GettableSilence, filter v1alpha1.Matchers) bool {
+	for _, f := range filter {
+		found := false
+
+		for _, m := range s.Matchers {
+			if matcherEqual(m, f) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
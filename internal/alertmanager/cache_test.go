@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"k8s.io/utils/ptr"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func gettableSilence(id string, matchers ...*models.Matcher) *models.GettableSilence {
+	return &models.GettableSilence{
+		ID: ptr.To(id),
+		Silence: models.Silence{
+			Matchers: matchers,
+		},
+	}
+}
+
+func matcher(name, value string, isEqual, isRegex bool) *models.Matcher {
+	return &models.Matcher{
+		Name:    ptr.To(name),
+		Value:   ptr.To(value),
+		IsEqual: ptr.To(isEqual),
+		IsRegex: ptr.To(isRegex),
+	}
+}
+
+func TestSilenceCacheFind(t *testing.T) {
+	c := newSilenceCache(0)
+
+	if _, ok := c.find(nil); ok {
+		t.Fatal("expected cache to be unsynced")
+	}
+
+	c.replace(models.GettableSilences{
+		gettableSilence("a", matcher("alertname", "Foo", true, false), matcher("env", "prod", true, true)),
+		gettableSilence("b", matcher("alertname", "Foo", true, true)),
+		gettableSilence("c", matcher("alertname", "Foo", false, false)),
+	})
+
+	found, ok := c.find(v1alpha1.Matchers{{Name: "alertname", Value: "Foo", MatchType: v1alpha1.MatchEqual}})
+	if !ok {
+		t.Fatal("expected cache to be synced")
+	}
+
+	if len(found) != 1 || *found[0].ID != "a" {
+		t.Fatalf("expected only silence a to match, got %d silences", len(found))
+	}
+
+	c.invalidate("a")
+
+	if _, ok := c.get("a"); ok {
+		t.Fatal("expected silence a to be invalidated")
+	}
+
+	if _, ok := c.get("b"); !ok {
+		t.Fatal("expected silence b to be cached")
+	}
+}
+
+func TestSilenceCacheStale(t *testing.T) {
+	c := newSilenceCache(time.Minute)
+	c.replace(models.GettableSilences{gettableSilence("a")})
+	c.replaceAlerts(models.GettableAlerts{})
+
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected silence a to be cached")
+	}
+
+	c.refreshed = time.Now().Add(-2 * time.Minute)
+	c.alertsRefreshed = c.refreshed
+
+	if _, ok := c.get("a"); ok {
+		t.Fatal("expected the stale snapshot not to be used")
+	}
+
+	if _, ok := c.find(nil); ok {
+		t.Fatal("expected the stale snapshot not to be searched")
+	}
+
+	if _, ok := c.getAlerts(); ok {
+		t.Fatal("expected the stale alerts not to be used")
+	}
+}
+
+func TestSilenceCacheSet(t *testing.T) {
+	c := newSilenceCache(time.Minute)
+	c.set(gettableSilence("a"))
+
+	if len(c.silences) != 0 {
+		t.Fatalf("expected nothing to be stored before the first sync, got %d silences", len(c.silences))
+	}
+
+	c.replace(models.GettableSilences{})
+	c.set(gettableSilence("a"))
+
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected silence a to be cached")
+	}
+
+	c.refreshed = time.Now().Add(-2 * time.Minute)
+	c.set(gettableSilence("b"))
+
+	if _, ok := c.silences["b"]; ok {
+		t.Fatal("expected nothing to be stored in a stale snapshot")
+	}
+}
+
+func TestSilenceCacheReplaceLost(t *testing.T) {
+	c := newSilenceCache(0)
+
+	active := func(id string) *models.GettableSilence {
+		s := gettableSilence(id)
+		s.Status = &models.SilenceStatus{State: ptr.To(models.SilenceStatusStateActive)}
+
+		return s
+	}
+
+	expired := gettableSilence("c")
+	expired.Status = &models.SilenceStatus{State: ptr.To(models.SilenceStatusStateExpired)}
+
+	if lost, _ := c.replace(models.GettableSilences{active("a"), active("b"), expired}); len(lost) != 0 {
+		t.Fatalf("expected nothing to be lost on the first sync, got %v", lost)
+	}
+
+	lost, unexpired := c.replace(models.GettableSilences{active("b")})
+	if len(lost) != 1 || lost[0] != "a" || len(unexpired) != 2 {
+		t.Fatalf("expected silence a of 2 unexpired silences to be lost, got %v of %v", lost, unexpired)
+	}
+}
+
+func TestSilenceCacheRetainLost(t *testing.T) {
+	c := newSilenceCache(0)
+	c.setLost([]string{"a", "b"})
+
+	c.retainLost([]string{"b", "c"})
+	if c.isLost("a") || !c.isLost("b") {
+		t.Fatal("expected only the re-created silence a to be forgotten")
+	}
+
+	c.retainLost(nil)
+	if c.hasLost() {
+		t.Fatal("expected all lost silences to be forgotten once recovered")
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/alertmanager/api/v2/client"
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
 	"github.com/prometheus/alertmanager/api/v2/models"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,7 +35,7 @@ import (
 type AlertManagerInterface interface {
 	GetSilence(id string) (*silence.GetSilenceOK, error)
 	GetSilences(filter []string) (*silence.GetSilencesOK, error)
-	UpsertSilence(s *v1alpha1.Silence, startsAt *strfmt.DateTime) (string, error)
+	UpsertSilence(ctx context.Context, s *v1alpha1.Silence, matchers v1alpha1.Matchers, startsAt *strfmt.DateTime) (string, error)
 	DeleteSilence(id string) error
 }
 
@@ -42,8 +43,19 @@ type AlertManager struct {
 	Author          string
 	InstanceName    string
 	SilenceDuration time.Duration
+	RefreshInterval time.Duration
 
-	am *client.AlertmanagerAPI
+	// MassLossThreshold is the fraction of the unexpired tracked silences which has to disappear between two
+	// refreshes to be reported as a mass loss. Zero disables the detection.
+	MassLossThreshold float64
+
+	// TrackedIDs returns the IDs of the silences managed by the operator. Mass losses are only detected
+	// among them, so silences created by others don't count. Without it, the detection is disabled.
+	TrackedIDs func(ctx context.Context) ([]string, error)
+
+	am     *client.AlertmanagerAPI
+	cache  *silenceCache
+	losses chan MassLoss
 }
 
 func (c *AlertManager) GetSilences(filter []string) (*silence.GetSilencesOK, error) {
@@ -52,35 +64,147 @@ func (c *AlertManager) GetSilences(filter []string) (*silence.GetSilencesOK, err
 	})
 }
 
+// GetSilence returns the silence from the cached snapshot and falls back to alertmanager
+// if the silence is not there, e.g. it was created or updated after the last refresh.
 func (c *AlertManager) GetSilence(id string) (*silence.GetSilenceOK, error) {
-	return c.am.Silence.GetSilence(&silence.GetSilenceParams{
+	if s, ok := c.cache.get(id); ok {
+		return &silence.GetSilenceOK{Payload: s}, nil
+	}
+
+	result, err := c.am.Silence.GetSilence(&silence.GetSilenceParams{
 		SilenceID: strfmt.UUID(id),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.set(result.GetPayload())
+
+	return result, nil
+}
+
+// findSilences returns silences matching all the given matchers.
+// The cached snapshot is used when it is available.
+func (c *AlertManager) findSilences(matchers v1alpha1.Matchers) (models.GettableSilences, error) {
+	if silences, ok := c.cache.find(matchers); ok {
+		return silences, nil
+	}
+
+	result, err := c.GetSilences(matchers.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.GetPayload(), nil
+}
+
+// Refresh replaces the cached snapshot with all silences and alerts currently known to alertmanager.
+func (c *AlertManager) Refresh(ctx context.Context) error {
+	result, err := c.GetSilences(nil)
+	if err != nil {
+		return err
+	}
+
+	lost, unexpired := c.cache.replace(result.GetPayload())
+
+	if c.MassLossThreshold > 0 && c.TrackedIDs != nil && (len(lost) > 0 || c.cache.hasLost()) {
+		tracked, err := c.TrackedIDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		c.cache.retainLost(tracked)
+
+		if loss, ok := detectMassLoss(lost, unexpired, tracked, c.MassLossThreshold); ok {
+			c.cache.setLost(loss.Lost)
+
+			// A loss which is still being recovered from already triggers the re-creation of all silences
+			select {
+			case c.losses <- loss:
+			default:
+			}
+		}
+	}
+
+	alerts, err := c.am.Alert.GetAlerts(&alert.GetAlertsParams{})
+	if err != nil {
+		return err
+	}
+
+	c.cache.replaceAlerts(alerts.GetPayload())
+
+	return nil
+}
+
+// Start refreshes the silences snapshot every RefreshInterval until the context is cancelled.
+// It implements manager.Runnable, so the client can be added to the controller manager.
+func (c *AlertManager) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("alertmanager-cache")
+
+	if c.RefreshInterval <= 0 {
+		log.Info("silences cache is disabled")
+
+		return nil
+	}
+
+	ticker := time.NewTicker(c.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil {
+			log.Error(err, "unable to refresh silences cache")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Duration returns the length of the silence window for the given object.
+func (c *AlertManager) Duration(s *v1alpha1.Silence) time.Duration {
+	if s.Spec.Duration != nil && s.Spec.Duration.Duration > 0 {
+		return s.Spec.Duration.Duration
+	}
+
+	return c.SilenceDuration
+}
+
+// EndsAt returns the end of the silence window starting now, limited by the silence lifetime.
+func (c *AlertManager) EndsAt(s *v1alpha1.Silence, now time.Time) time.Time {
+	endsAt := now.Add(c.Duration(s))
+
+	if expiresAt := s.ExpiresAt(); expiresAt != nil && expiresAt.Before(endsAt) {
+		return *expiresAt
+	}
+
+	return endsAt
 }
 
 // UpsertSilence will check if there is a silence with the same matchers.
 // It will update it if it exists and create a new one if it doesn't.
-func (c *AlertManager) UpsertSilence(ctx context.Context, s *v1alpha1.Silence, startsAt *strfmt.DateTime) (string, error) {
+// The matchers are passed separately, as they might be rendered from other sources than the spec.
+func (c *AlertManager) UpsertSilence(
+	ctx context.Context, s *v1alpha1.Silence, matchers v1alpha1.Matchers, startsAt *strfmt.DateTime,
+) (string, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	if s.Status.AlertManagerID == "" {
 		found := false
 
-		filter := s.Spec.Matchers.String()
-
-		result, err := c.GetSilences(filter)
+		existingSilences, err := c.findSilences(matchers)
 		if err != nil {
 			return "", err
 		}
 
-		existingSilences := result.GetPayload()
-
 		for _, existingSilence := range existingSilences {
 			if *existingSilence.Status.State == models.SilenceStatusStateExpired {
 				continue
 			}
 
-			if len(existingSilence.Matchers) == len(s.Spec.Matchers) {
+			if len(existingSilence.Matchers) == len(matchers) {
 				log.Info("found an existing silence, updating existing silence", "silence", existingSilence.ID)
 
 				s.Status.AlertManagerID = *existingSilence.ID
@@ -95,17 +219,6 @@ func (c *AlertManager) UpsertSilence(ctx context.Context, s *v1alpha1.Silence, s
 		}
 	}
 
-	matchers := models.Matchers{}
-
-	for _, m := range s.Spec.Matchers {
-		matchers = append(matchers, &models.Matcher{
-			IsEqual: &m.IsEqual,
-			IsRegex: &m.IsRegex,
-			Name:    &m.Name,
-			Value:   &m.Value,
-		})
-	}
-
 	now := time.Now()
 
 	if startsAt == nil {
@@ -113,20 +226,8 @@ func (c *AlertManager) UpsertSilence(ctx context.Context, s *v1alpha1.Silence, s
 		startsAt = &nowFmt
 	}
 
-	endsAt := strfmt.DateTime(now.Add(c.SilenceDuration))
-	comment := fmt.Sprintf("%s\nInstance: %s", s.Spec.Comment, c.InstanceName)
-
 	result, err := c.am.Silence.PostSilences(&silence.PostSilencesParams{
-		Silence: &models.PostableSilence{
-			ID: s.Status.AlertManagerID,
-			Silence: models.Silence{
-				Comment:   &comment,
-				CreatedBy: &c.Author,
-				EndsAt:    &endsAt,
-				StartsAt:  startsAt,
-				Matchers:  matchers,
-			},
-		},
+		Silence: c.postableSilence(s, matchers, *startsAt, now),
 	})
 	if err != nil {
 		return "", err
@@ -135,22 +236,50 @@ func (c *AlertManager) UpsertSilence(ctx context.Context, s *v1alpha1.Silence, s
 	newId := result.GetPayload().SilenceID
 	log.Info("silence created", "id", newId)
 
+	// Alertmanager might replace the silence with a new one, so both are stale now
+	c.cache.invalidate(s.Status.AlertManagerID)
+	c.cache.invalidate(newId)
+
 	return newId, nil
 }
 
+// postableSilence returns the alertmanager silence of the object, starting at startsAt
+// and ending after the silence window starting now.
+func (c *AlertManager) postableSilence(
+	s *v1alpha1.Silence, matchers v1alpha1.Matchers, startsAt strfmt.DateTime, now time.Time,
+) *models.PostableSilence {
+	endsAt := strfmt.DateTime(c.EndsAt(s, now))
+	comment := fmt.Sprintf("%s\nInstance: %s", s.Spec.Comment, c.InstanceName)
+
+	return &models.PostableSilence{
+		ID: s.Status.AlertManagerID,
+		Silence: models.Silence{
+			Comment:   &comment,
+			CreatedBy: &c.Author,
+			EndsAt:    &endsAt,
+			StartsAt:  &startsAt,
+			Matchers:  toModels(matchers),
+		},
+	}
+}
+
 func (c *AlertManager) DeleteSilence(id string) error {
 	_, err := c.am.Silence.DeleteSilence(&silence.DeleteSilenceParams{
 		SilenceID: strfmt.UUID(id),
 	})
 
+	c.cache.invalidate(id)
+
 	return err
 }
 
 type Config struct {
-	URL             string
-	Author          string
-	InstanceName    string
-	SilenceDuration time.Duration
+	URL               string
+	Author            string
+	InstanceName      string
+	SilenceDuration   time.Duration
+	RefreshInterval   time.Duration
+	MassLossThreshold float64
 }
 
 func New(cfg *Config) (*AlertManager, error) {
@@ -167,11 +296,18 @@ func New(cfg *Config) (*AlertManager, error) {
 		WithHost(amURL.Host).
 		WithSchemes([]string{amURL.Scheme})
 
-	return &AlertManager{
-		Author:          cfg.Author,
-		InstanceName:    cfg.InstanceName,
-		SilenceDuration: cfg.SilenceDuration,
+	// A snapshot which missed two refreshes in a row is stale, alertmanager may have changed meanwhile
+	cache := newSilenceCache(2 * cfg.RefreshInterval)
 
-		am: client.NewHTTPClientWithConfig(strfmt.Default, transportConfig),
+	return &AlertManager{
+		Author:            cfg.Author,
+		InstanceName:      cfg.InstanceName,
+		SilenceDuration:   cfg.SilenceDuration,
+		RefreshInterval:   cfg.RefreshInterval,
+		MassLossThreshold: cfg.MassLossThreshold,
+
+		am:     client.NewHTTPClientWithConfig(strfmt.Default, transportConfig),
+		cache:  cache,
+		losses: make(chan MassLoss, 1),
 	}, nil
 }
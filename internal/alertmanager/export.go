@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+const (
+	// ExportFormatJSON is a JSON array of alertmanager PostableSilences without IDs,
+	// so every silence is created as a new one, e.g. in a rebuilt alertmanager.
+	ExportFormatJSON = "json"
+
+	// ExportFormatAmtool is the input of `amtool silence import`. It keeps the IDs:
+	// amtool updates the silences which still exist and creates the missing ones.
+	ExportFormatAmtool = "amtool"
+)
+
+// ExportPath is the path of the export handler on the metrics server.
+const ExportPath = "/silences/export"
+
+// Export returns the alertmanager silences of the active Silence objects, as the operator applies them
+// starting now. The matchers are the applied ones recorded in the status, so rendered matchers are
+// included. Silences whose applied matchers weren't recorded yet are left out.
+func (c *AlertManager) Export(silences []v1alpha1.Silence, format string, now time.Time) ([]*models.PostableSilence, error) {
+	if format != ExportFormatJSON && format != ExportFormatAmtool {
+		return nil, fmt.Errorf("unknown export format %q, use %s or %s", format, ExportFormatJSON, ExportFormatAmtool)
+	}
+
+	out := []*models.PostableSilence{}
+
+	for i := range silences {
+		s := &silences[i]
+
+		if !s.Status.Active || s.Spec.Suspend || len(s.Status.AppliedMatchers) == 0 || !c.EndsAt(s, now).After(now) {
+			continue
+		}
+
+		silence := c.postableSilence(s, s.Status.AppliedMatchers, strfmt.DateTime(now), now)
+		if format == ExportFormatJSON {
+			silence.ID = ""
+		}
+
+		out = append(out, silence)
+	}
+
+	return out, nil
+}
+
+// ExportHandler serves the alertmanager silences of all Silence objects, see Export.
+// The format query parameter selects the format, json by default.
+func ExportHandler(reader client.Reader, am *AlertManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = ExportFormatJSON
+		}
+
+		list := &v1alpha1.SilenceList{}
+		if err := reader.List(r.Context(), list); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		silences, err := am.Export(list.Items, format, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(silences); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
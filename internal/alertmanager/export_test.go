@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestExport(t *testing.T) {
+	am := &AlertManager{Author: "silence-operator", InstanceName: "test", SilenceDuration: time.Hour}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	active := v1alpha1.Silence{
+		Spec: v1alpha1.SilenceSpec{Comment: "maintenance"},
+		Status: v1alpha1.SilenceStatus{
+			AlertManagerID: "5b1e1a2c-7a6f-4c1e-9f3e-2d1c0b9a8f7e",
+			Active:         true,
+			Matchers:       `{alertname="Foo", severity=~"info|warning", summary="a, b"}`,
+			AppliedMatchers: v1alpha1.Matchers{
+				{Name: "alertname", Value: "Foo", MatchType: v1alpha1.MatchEqual},
+				{Name: "severity", Value: "info|warning", MatchType: v1alpha1.MatchRegexp},
+				{Name: "summary", Value: "a, b", MatchType: v1alpha1.MatchEqual},
+			},
+		},
+	}
+	suspended := *active.DeepCopy()
+	suspended.Spec.Suspend = true
+
+	pending := v1alpha1.Silence{Spec: v1alpha1.SilenceSpec{Comment: "not applied yet"}}
+
+	unrecorded := *active.DeepCopy()
+	unrecorded.Status.AppliedMatchers = nil
+
+	for _, format := range []string{ExportFormatJSON, ExportFormatAmtool} {
+		t.Run(format, func(t *testing.T) {
+			silences, err := am.Export([]v1alpha1.Silence{active, suspended, pending, unrecorded}, format, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(silences) != 1 {
+				t.Fatalf("Export() returned %d silences, want 1", len(silences))
+			}
+
+			s := silences[0]
+
+			if wantID := format == ExportFormatAmtool; (s.ID != "") != wantID {
+				t.Errorf("unexpected ID %q", s.ID)
+			}
+
+			if !MatchersEqual(s.Matchers, v1alpha1.Matchers{
+				{Name: "alertname", Value: "Foo", MatchType: v1alpha1.MatchEqual},
+				{Name: "severity", Value: "info|warning", MatchType: v1alpha1.MatchRegexp},
+				{Name: "summary", Value: "a, b", MatchType: v1alpha1.MatchEqual},
+			}) {
+				t.Errorf("unexpected matchers %v", s.Matchers)
+			}
+
+			if got := time.Time(*s.EndsAt); !got.Equal(now.Add(time.Hour)) {
+				t.Errorf("EndsAt = %v, want %v", got, now.Add(time.Hour))
+			}
+		})
+	}
+
+	if _, err := am.Export(nil, "yaml", now); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import "slices"
+
+// MassLoss is reported when most of the unexpired silences disappeared from alertmanager between
+// two refreshes, e.g. because it was restarted without persistent storage.
+type MassLoss struct {
+	// Lost are the IDs of the silences which disappeared.
+	Lost []string
+
+	// Unexpired is the number of unexpired tracked silences before the loss.
+	Unexpired int
+}
+
+// detectMassLoss reports a mass loss if at least the threshold fraction of the unexpired tracked silences was lost.
+func detectMassLoss(lost, unexpired, tracked []string, threshold float64) (MassLoss, bool) {
+	isTracked := func(id string) bool { return slices.Contains(tracked, id) }
+
+	lost = slices.DeleteFunc(slices.Clone(lost), func(id string) bool { return !isTracked(id) })
+	count := len(slices.DeleteFunc(slices.Clone(unexpired), func(id string) bool { return !isTracked(id) }))
+
+	if len(lost) == 0 || float64(len(lost)) < threshold*float64(count) {
+		return MassLoss{}, false
+	}
+
+	return MassLoss{Lost: lost, Unexpired: count}, true
+}
+
+// MassLosses returns the channel receiving the detected mass losses.
+func (c *AlertManager) MassLosses() <-chan MassLoss {
+	return c.losses
+}
+
+// Lost reports whether the silence disappeared in the last mass loss, so it doesn't have to be looked up again.
+func (c *AlertManager) Lost(id string) bool {
+	return c.cache.isLost(id)
+}
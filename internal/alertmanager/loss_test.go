@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetectMassLoss(t *testing.T) {
+	tests := []struct {
+		name      string
+		lost      []string
+		unexpired []string
+		tracked   []string
+		want      []string
+	}{
+		{
+			name:      "most tracked silences lost",
+			lost:      []string{"a", "b"},
+			unexpired: []string{"a", "b", "c"},
+			tracked:   []string{"a", "b", "c"},
+			want:      []string{"a", "b"},
+		},
+		{
+			name:      "few tracked silences lost",
+			lost:      []string{"a"},
+			unexpired: []string{"a", "b", "c"},
+			tracked:   []string{"a", "b", "c"},
+		},
+		{
+			name:      "untracked silences are ignored",
+			lost:      []string{"x", "y", "a"},
+			unexpired: []string{"x", "y", "a", "b", "c"},
+			tracked:   []string{"a", "b", "c"},
+		},
+		{
+			name:      "untracked silences don't dilute the loss",
+			lost:      []string{"a", "b"},
+			unexpired: []string{"a", "b", "x", "y", "z"},
+			tracked:   []string{"a", "b"},
+			want:      []string{"a", "b"},
+		},
+		{
+			name:      "nothing tracked",
+			lost:      []string{"a"},
+			unexpired: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loss, ok := detectMassLoss(tt.lost, tt.unexpired, tt.tracked, 0.5)
+			if ok != (tt.want != nil) || !slices.Equal(loss.Lost, tt.want) {
+				t.Fatalf("expected loss of %v, got %v (%t)", tt.want, loss.Lost, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// toModels converts matchers into the alertmanager API representation.
+func toModels(matchers v1alpha1.Matchers) models.Matchers {
+	out := models.Matchers{}
+
+	for _, m := range matchers {
+		matchType := m.Type()
+
+		out = append(out, &models.Matcher{
+			IsEqual: ptr.To(matchType == labels.MatchEqual || matchType == labels.MatchRegexp),
+			IsRegex: ptr.To(matchType == labels.MatchRegexp || matchType == labels.MatchNotRegexp),
+			Name:    &m.Name,
+			Value:   &m.Value,
+		})
+	}
+
+	return out
+}
+
+// FromModels converts matchers of the alertmanager API, e.g. of an existing silence.
+func FromModels(matchers models.Matchers) v1alpha1.Matchers {
+	out := v1alpha1.Matchers{}
+
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+
+		isEqual := m.IsEqual == nil || *m.IsEqual
+		isRegex := m.IsRegex != nil && *m.IsRegex
+
+		matchType := v1alpha1.MatchNotEqual
+
+		switch {
+		case isEqual && isRegex:
+			matchType = v1alpha1.MatchRegexp
+		case !isEqual && isRegex:
+			matchType = v1alpha1.MatchNotRegexp
+		case isEqual && !isRegex:
+			matchType = v1alpha1.MatchEqual
+		}
+
+		out = append(out, v1alpha1.Matcher{
+			Name:      *m.Name,
+			Value:     *m.Value,
+			MatchType: matchType,
+		})
+	}
+
+	return out
+}
+
+// toLabelMatchers converts matchers into alertmanager label matchers, so they can be evaluated locally.
+func toLabelMatchers(matchers v1alpha1.Matchers) (labels.Matchers, error) {
+	out := labels.Matchers{}
+
+	for _, m := range matchers {
+		matcher, err := labels.NewMatcher(m.Type(), m.Name, m.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, matcher)
+	}
+
+	return out, nil
+}
+
+// matcherEqual reports whether the alertmanager matcher is the same as the given one.
+func matcherEqual(m *models.Matcher, matcher v1alpha1.Matcher) bool {
+	if m == nil || m.Name == nil || m.Value == nil || m.IsRegex == nil {
+		return false
+	}
+
+	// IsEqual was added later to the alertmanager API and defaults to true
+	isEqual := m.IsEqual == nil || *m.IsEqual
+
+	matchType := matcher.Type()
+
+	return *m.Name == matcher.Name && *m.Value == matcher.Value &&
+		isEqual == (matchType == labels.MatchEqual || matchType == labels.MatchRegexp) &&
+		*m.IsRegex == (matchType == labels.MatchRegexp || matchType == labels.MatchNotRegexp)
+}
+
+// MatchersEqual reports whether the alertmanager matchers are the same as the given ones, ignoring order.
+func MatchersEqual(existing models.Matchers, matchers v1alpha1.Matchers) bool {
+	if len(existing) != len(matchers) {
+		return false
+	}
+
+	for _, matcher := range matchers {
+		found := false
+
+		for _, m := range existing {
+			if matcherEqual(m, matcher) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestToLabelMatchers(t *testing.T) {
+	alert := model.LabelSet{"alertname": "HighLatency", "env": "prod", "severity": "warning"}
+
+	tests := []struct {
+		name     string
+		matchers v1alpha1.Matchers
+		want     bool
+	}{
+		{
+			name:     "equal",
+			matchers: v1alpha1.Matchers{{Name: "alertname", Value: "HighLatency", MatchType: v1alpha1.MatchEqual}},
+			want:     true,
+		},
+		{
+			name:     "regex is anchored",
+			matchers: v1alpha1.Matchers{{Name: "alertname", Value: "High", MatchType: v1alpha1.MatchRegexp}},
+			want:     false,
+		},
+		{
+			name:     "not regex",
+			matchers: v1alpha1.Matchers{{Name: "severity", Value: "critical|page", MatchType: v1alpha1.MatchNotRegexp}},
+			want:     true,
+		},
+		{
+			name: "all matchers must match",
+			matchers: v1alpha1.Matchers{
+				{Name: "alertname", Value: "HighLatency", MatchType: v1alpha1.MatchEqual},
+				{Name: "env", Value: "prod", MatchType: v1alpha1.MatchNotEqual},
+			},
+			want: false,
+		},
+		{
+			name:     "missing label is empty",
+			matchers: v1alpha1.Matchers{{Name: "team", Value: "", MatchType: v1alpha1.MatchEqual}},
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labelMatchers, err := toLabelMatchers(tt.matchers)
+			if err != nil {
+				t.Fatalf("toLabelMatchers() error = %v", err)
+			}
+
+			if got := labelMatchers.Matches(alert); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromModels(t *testing.T) {
+	matchers := v1alpha1.Matchers{
+		{Name: "alertname", Value: "HighLatency", MatchType: v1alpha1.MatchEqual},
+		{Name: "env", Value: "dev", MatchType: v1alpha1.MatchNotEqual},
+		{Name: "severity", Value: "info|warning", MatchType: v1alpha1.MatchRegexp},
+		{Name: "team", Value: "ops.*", MatchType: v1alpha1.MatchNotRegexp},
+	}
+
+	got := FromModels(toModels(matchers))
+
+	if len(got) != len(matchers) {
+		t.Fatalf("FromModels() = %v, want %v", got, matchers)
+	}
+
+	for i := range matchers {
+		if got[i] != matchers[i] {
+			t.Errorf("FromModels()[%d] = %v, want %v", i, got[i], matchers[i])
+		}
+	}
+}
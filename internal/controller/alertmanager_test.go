@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"k8s.io/utils/ptr"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+// fakeAlertManager serves the silence endpoints of the alertmanager API from memory.
+// Like alertmanager, it replaces a silence on update if the silence expired or its matchers changed.
+type fakeAlertManager struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	silences map[string]*models.GettableSilence
+	nextID   int
+
+	// failLabel fails all requests for silences with a matcher on this label.
+	failLabel string
+}
+
+func newFakeAlertManager() *fakeAlertManager {
+	am := &fakeAlertManager{silences: map[string]*models.GettableSilence{}}
+	am.Server = httptest.NewServer(http.HandlerFunc(am.serve))
+
+	return am
+}
+
+// client returns an alertmanager client of the fake without silences cache.
+func (am *fakeAlertManager) client() *alertmanager.AlertManager {
+	c, err := alertmanager.New(&alertmanager.Config{
+		URL:             am.URL,
+		Author:          "silence-operator",
+		InstanceName:    "test",
+		SilenceDuration: time.Hour,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// active returns the IDs of the silences which are not expired.
+func (am *fakeAlertManager) active() []string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	ids := []string{}
+
+	for id, s := range am.silences {
+		if *s.Status.State != models.SilenceStatusStateExpired {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// expire expires the silence as if it ended or was expired by someone else.
+func (am *fakeAlertManager) expire(id string) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if s, ok := am.silences[id]; ok {
+		s.Status.State = ptr.To(models.SilenceStatusStateExpired)
+	}
+}
+
+func (am *fakeAlertManager) serve(w http.ResponseWriter, r *http.Request) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	id, single := strings.CutPrefix(r.URL.Path, "/api/v2/silence/")
+
+	switch {
+	case r.URL.Path == "/api/v2/alerts":
+		writeJSON(w, models.GettableAlerts{})
+	case r.URL.Path == "/api/v2/silences" && r.Method == http.MethodGet:
+		am.list(w, r.URL.Query()["filter"])
+	case r.URL.Path == "/api/v2/silences" && r.Method == http.MethodPost:
+		am.post(w, r)
+	case single && r.Method == http.MethodGet:
+		s, ok := am.silences[id]
+
+		switch {
+		case !ok:
+			http.Error(w, "not found", http.StatusNotFound)
+		case am.fails(s.Matchers):
+			http.Error(w, "failing", http.StatusInternalServerError)
+		default:
+			writeJSON(w, s)
+		}
+	case single && r.Method == http.MethodDelete:
+		s, ok := am.silences[id]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+
+			return
+		}
+
+		if am.fails(s.Matchers) {
+			http.Error(w, "failing", http.StatusInternalServerError)
+
+			return
+		}
+
+		s.Status.State = ptr.To(models.SilenceStatusStateExpired)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (am *fakeAlertManager) list(w http.ResponseWriter, filter []string) {
+	matchers := monitoringv1alpha1.Matchers{}
+
+	for _, f := range filter {
+		m, err := monitoringv1alpha1.ParseMatcher(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	out := models.GettableSilences{}
+
+	for _, s := range am.silences {
+		if matchesFilter(s.Matchers, matchers) {
+			out = append(out, s)
+		}
+	}
+
+	writeJSON(w, out)
+}
+
+func (am *fakeAlertManager) post(w http.ResponseWriter, r *http.Request) {
+	postable := &models.PostableSilence{}
+	if err := json.NewDecoder(r.Body).Decode(postable); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if am.fails(postable.Matchers) {
+		http.Error(w, "failing", http.StatusInternalServerError)
+
+		return
+	}
+
+	id := postable.ID
+
+	if id != "" {
+		existing, ok := am.silences[id]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+
+			return
+		}
+
+		if *existing.Status.State == models.SilenceStatusStateExpired ||
+			!alertmanager.MatchersEqual(existing.Matchers, alertmanager.FromModels(postable.Matchers)) {
+			existing.Status.State = ptr.To(models.SilenceStatusStateExpired)
+			id = ""
+		}
+	}
+
+	if id == "" {
+		am.nextID++
+		id = fmt.Sprintf("silence-%d", am.nextID)
+	}
+
+	now := strfmt.DateTime(time.Now())
+	am.silences[id] = &models.GettableSilence{
+		ID:        ptr.To(id),
+		Status:    &models.SilenceStatus{State: ptr.To(models.SilenceStatusStateActive)},
+		UpdatedAt: &now,
+		Silence:   postable.Silence,
+	}
+
+	writeJSON(w, map[string]string{"silenceID": id})
+}
+
+func (am *fakeAlertManager) fails(matchers models.Matchers) bool {
+	for _, m := range matchers {
+		if am.failLabel != "" && m.Name != nil && *m.Name == am.failLabel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesFilter reports whether every filter matcher is one of the silence matchers.
+func matchesFilter(matchers models.Matchers, filter monitoringv1alpha1.Matchers) bool {
+	for _, f := range filter {
+		found := false
+
+		for _, m := range matchers {
+			if alertmanager.MatchersEqual(models.Matchers{m}, monitoringv1alpha1.Matchers{f}) {
+				found = true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
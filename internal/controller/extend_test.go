@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+func TestExtendAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	am := &alertmanager.AlertManager{SilenceDuration: 4 * time.Hour}
+
+	tests := []struct {
+		name   string
+		spec   monitoringv1alpha1.SilenceSpec
+		endsAt time.Time
+		want   time.Time
+	}{
+		{
+			name:   "default duration",
+			endsAt: now.Add(4 * time.Hour),
+			want:   now.Add(3 * time.Hour),
+		},
+		{
+			name:   "silence duration",
+			spec:   monitoringv1alpha1.SilenceSpec{Duration: &metav1.Duration{Duration: 8 * time.Hour}},
+			endsAt: now.Add(8 * time.Hour),
+			want:   now.Add(6 * time.Hour),
+		},
+		{
+			name:   "window ends before the silence expires",
+			spec:   monitoringv1alpha1.SilenceSpec{EndsAt: &metav1.Time{Time: now.Add(24 * time.Hour)}},
+			endsAt: now.Add(4 * time.Hour),
+			want:   now.Add(3 * time.Hour),
+		},
+		{
+			name:   "window ends when the silence expires",
+			spec:   monitoringv1alpha1.SilenceSpec{EndsAt: &metav1.Time{Time: now.Add(2 * time.Hour)}},
+			endsAt: now.Add(2 * time.Hour),
+			want:   now.Add(2 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &monitoringv1alpha1.Silence{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now)},
+				Spec:       tt.spec,
+			}
+
+			if got := extendAt(am, 0.25, obj, tt.endsAt); !got.Equal(tt.want) {
+				t.Fatalf("expected extension at %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	interval := 5 * time.Minute
+
+	tests := []struct {
+		name  string
+		until time.Duration
+		min   time.Duration
+		max   time.Duration
+	}{
+		{name: "after the interval", until: time.Hour, min: interval, max: interval},
+		{name: "within the interval", until: 2 * time.Minute, min: 2*time.Minute - time.Second, max: 2 * time.Minute},
+		{name: "almost now", until: 100 * time.Millisecond, min: time.Second, max: time.Second},
+		{name: "in the past", until: -time.Hour, min: time.Second, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := requeueAfter(interval, time.Now().Add(tt.until))
+			if got < tt.min || got > tt.max {
+				t.Fatalf("expected requeue after %s to %s, got %s", tt.min, tt.max, got)
+			}
+		})
+	}
+}
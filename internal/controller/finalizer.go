@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FieldManager is the field manager of all changes the operator makes to objects.
+const FieldManager = "silence-operator"
+
+// addFinalizer adds the finalizer with a JSON patch, so only metadata.finalizers is sent and fields set by others,
+// e.g. GitOps tools applying the spec, are left alone. Finalizers are a set, so the patch carries no resourceVersion
+// and doesn't conflict with concurrent changes. It reports whether the finalizer was missing.
+func addFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) (bool, error) {
+	if controllerutil.ContainsFinalizer(obj, finalizer) {
+		return false, nil
+	}
+
+	ops := []jsonPatchOp{{Op: "add", Path: "/metadata/finalizers/-", Value: finalizer}}
+	if len(obj.GetFinalizers()) == 0 {
+		// The test fails if someone else added the first finalizer in the meantime, instead of replacing it
+		ops = []jsonPatchOp{
+			{Op: "test", Path: "/metadata/finalizers", Value: nil},
+			{Op: "add", Path: "/metadata/finalizers", Value: []string{finalizer}},
+		}
+	}
+
+	return true, patchFinalizers(ctx, c, obj, ops)
+}
+
+// removeFinalizer removes the finalizer with a JSON patch, see addFinalizer.
+// The patch tests the finalizer is still at its index, so finalizers added by others are never dropped.
+func removeFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) (bool, error) {
+	i := slices.Index(obj.GetFinalizers(), finalizer)
+	if i < 0 {
+		return false, nil
+	}
+
+	path := fmt.Sprintf("/metadata/finalizers/%d", i)
+
+	return true, patchFinalizers(ctx, c, obj, []jsonPatchOp{
+		{Op: "test", Path: path, Value: finalizer},
+		{Op: "remove", Path: path},
+	})
+}
+
+type jsonPatchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+func patchFinalizers(ctx context.Context, c client.Client, obj client.Object, ops []jsonPatchOp) error {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+
+	return c.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, data))
+}
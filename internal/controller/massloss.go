@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+var (
+	massLossesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "silence_operator_alertmanager_mass_losses_total",
+		Help: "Number of times alertmanager lost most of its silences.",
+	})
+
+	lostSilencesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "silence_operator_alertmanager_lost_silences_total",
+		Help: "Number of alertmanager silences lost in mass losses.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(massLossesTotal, lostSilencesTotal)
+}
+
+// MassLossRecovery re-creates the managed silences as soon as alertmanager lost most of them,
+// e.g. after a restart without persistent storage, instead of waiting for every object to notice
+// on its next reconciliation.
+type MassLossRecovery struct {
+	client.Client
+
+	AlertManager *alertmanager.AlertManager
+	Recorder     record.EventRecorder
+
+	// Pod is the operator pod the cluster-wide Warning event is recorded for. It is skipped without a name.
+	Pod types.NamespacedName
+
+	// Silences and SilenceGroups receive the objects to reconcile right away, the most important first.
+	Silences      chan<- event.GenericEvent
+	SilenceGroups chan<- event.GenericEvent
+}
+
+// Start handles the mass losses reported by the alertmanager client until the context is cancelled.
+func (m *MassLossRecovery) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case loss := <-m.AlertManager.MassLosses():
+			m.recover(ctx, loss)
+		}
+	}
+}
+
+func (m *MassLossRecovery) recover(ctx context.Context, loss alertmanager.MassLoss) {
+	log := ctrl.LoggerFrom(ctx).WithName("mass-loss-recovery")
+	log.Info("alertmanager lost most silences, re-creating all silences",
+		"lost", len(loss.Lost), "unexpired", loss.Unexpired)
+
+	massLossesTotal.Inc()
+	lostSilencesTotal.Add(float64(len(loss.Lost)))
+
+	if m.Recorder != nil && m.Pod.Name != "" {
+		m.Recorder.Eventf(&corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  m.Pod.Namespace,
+			Name:       m.Pod.Name,
+		}, corev1.EventTypeWarning, "SilencesLost",
+			"Alertmanager lost %d of %d silences, re-creating all managed silences", len(loss.Lost), loss.Unexpired)
+	}
+
+	silences := &monitoringv1alpha1.SilenceList{}
+	if err := m.List(ctx, silences); err != nil {
+		log.Error(err, "unable to list silences")
+	} else {
+		sortByRecoveryPriority(silences.Items, loss.Lost)
+
+		for i := range silences.Items {
+			s := &silences.Items[i]
+
+			if m.Recorder != nil && slices.Contains(loss.Lost, s.Status.AlertManagerID) {
+				m.Recorder.Eventf(s, corev1.EventTypeWarning, "SilenceLost",
+					"Alertmanager lost silence %s, it is re-created", s.Status.AlertManagerID)
+			}
+
+			if !send(ctx, m.Silences, s) {
+				return
+			}
+		}
+	}
+
+	groups := &monitoringv1alpha1.SilenceGroupList{}
+	if err := m.List(ctx, groups); err != nil {
+		log.Error(err, "unable to list silence groups")
+
+		return
+	}
+
+	for i := range groups.Items {
+		if !send(ctx, m.SilenceGroups, &groups.Items[i]) {
+			return
+		}
+	}
+}
+
+// TrackedIDs returns a function listing the alertmanager IDs recorded on Silence and SilenceGroup objects,
+// so mass losses are detected among the silences managed by the operator only.
+func TrackedIDs(reader client.Reader) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		silences := &monitoringv1alpha1.SilenceList{}
+		if err := reader.List(ctx, silences); err != nil {
+			return nil, err
+		}
+
+		groups := &monitoringv1alpha1.SilenceGroupList{}
+		if err := reader.List(ctx, groups); err != nil {
+			return nil, err
+		}
+
+		ids := []string{}
+		for _, s := range silences.Items {
+			if s.Status.AlertManagerID != "" {
+				ids = append(ids, s.Status.AlertManagerID)
+			}
+		}
+		for i := range groups.Items {
+			ids = append(ids, groups.Items[i].Status.RecordedIDs()...)
+		}
+
+		return ids, nil
+	}
+}
+
+// sortByRecoveryPriority puts the lost silences first. Among them, silences muting more alerts come first,
+// as their alerts are firing again right now.
+func sortByRecoveryPriority(silences []monitoringv1alpha1.Silence, lost []string) {
+	slices.SortStableFunc(silences, func(a, b monitoringv1alpha1.Silence) int {
+		aLost, bLost := slices.Contains(lost, a.Status.AlertManagerID), slices.Contains(lost, b.Status.AlertManagerID)
+
+		switch {
+		case aLost && !bLost:
+			return -1
+		case !aLost && bLost:
+			return 1
+		}
+
+		return b.Status.MutedAlerts - a.Status.MutedAlerts
+	})
+}
+
+// send queues the object for reconciliation. It returns false if the context was cancelled meanwhile.
+func send(ctx context.Context, ch chan<- event.GenericEvent, obj client.Object) bool {
+	if ch == nil {
+		return true
+	}
+
+	select {
+	case ch <- event.GenericEvent{Object: obj}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// NamespaceSilenceName is the name of the Silence generated for annotated namespaces.
+const NamespaceSilenceName = "namespace-silence"
+
+// NamespaceReconciler keeps a Silence for namespaces annotated with the silence-until annotation.
+type NamespaceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile creates the namespace silence while the namespace is annotated and deletes it afterwards.
+func (r *NamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, req.NamespacedName, ns); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	silenceKey := types.NamespacedName{
+		Namespace: ns.Name,
+		Name:      NamespaceSilenceName,
+	}
+
+	until, annotated := ns.Annotations[monitoringv1alpha1.NamespaceSilenceUntilAnnotation]
+	if !annotated || !ns.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deleteSilence(ctx, ns, silenceKey)
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		log.Error(err, "unable to parse namespace silence end", "annotation", monitoringv1alpha1.NamespaceSilenceUntilAnnotation)
+
+		return ctrl.Result{}, nil
+	}
+
+	matchers := monitoringv1alpha1.Matchers{}
+
+	if value, ok := ns.Annotations[monitoringv1alpha1.NamespaceSilenceMatchersAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &matchers); err != nil {
+			log.Error(err, "unable to parse namespace silence matchers",
+				"annotation", monitoringv1alpha1.NamespaceSilenceMatchersAnnotation)
+
+			return ctrl.Result{}, nil
+		}
+	}
+
+	matchers = append(monitoringv1alpha1.Matchers{{
+		Name:      "namespace",
+		Value:     ns.Name,
+		MatchType: monitoringv1alpha1.MatchEqual,
+	}}, matchers...)
+
+	s := &monitoringv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      silenceKey.Name,
+			Namespace: silenceKey.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, s, func() error {
+		if err := ensureControlledBy(s, ns); err != nil {
+			return err
+		}
+
+		s.Spec.Comment = fmt.Sprintf("Namespace %s is silenced until %s", ns.Name, until)
+		s.Spec.Matchers = matchers
+		s.Spec.EndsAt = &metav1.Time{Time: endsAt}
+
+		return controllerutil.SetControllerReference(ns, s, r.Scheme)
+	})
+	if errors.Is(err, errNotControlled) {
+		log.Error(err, "conflicting namespace silence, leaving it alone", "silence", silenceKey)
+
+		return ctrl.Result{}, nil
+	}
+
+	if err != nil {
+		log.Error(err, "unable to create or update namespace silence", "silence", silenceKey)
+
+		return ctrl.Result{}, err
+	}
+
+	if op != controllerutil.OperationResultNone {
+		log.Info("namespace silence applied", "silence", silenceKey, "operation", op, "until", until)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteSilence deletes the namespace silence if it is controlled by the given namespace.
+func (r *NamespaceReconciler) deleteSilence(ctx context.Context, ns *corev1.Namespace, key types.NamespacedName) error {
+	s := &monitoringv1alpha1.Silence{}
+	if err := r.Get(ctx, key, s); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(s, ns) || !s.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("deleting namespace silence", "silence", key)
+
+	if err := r.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}).
+		Owns(&monitoringv1alpha1.Silence{}).
+		Named("namespace").
+		Complete(r)
+}
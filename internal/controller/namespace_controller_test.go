@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+var _ = Describe("Namespace Controller", func() {
+	var reconciler *NamespaceReconciler
+
+	until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	// create creates the namespace annotated with the silence end
+	create := func(name string) {
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{monitoringv1alpha1.NamespaceSilenceUntilAnnotation: until},
+			},
+		})).To(Succeed())
+	}
+
+	reconcileNamespace := func(name string) error {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+
+		return err
+	}
+
+	getSilence := func(namespace string) *monitoringv1alpha1.Silence {
+		s := &monitoringv1alpha1.Silence{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: NamespaceSilenceName}, s)).To(Succeed())
+
+		return s
+	}
+
+	BeforeEach(func() {
+		reconciler = &NamespaceReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+	})
+
+	It("creates a silence matching the namespace exactly", func() {
+		const name = "namespace-silence-generated"
+		create(name)
+		Expect(reconcileNamespace(name)).To(Succeed())
+
+		s := getSilence(name)
+		Expect(s.Spec.Matchers).To(Equal(monitoringv1alpha1.Matchers{
+			{Name: "namespace", Value: name, MatchType: monitoringv1alpha1.MatchEqual},
+		}))
+		Expect(s.OwnerReferences).To(HaveLen(1))
+		Expect(*s.OwnerReferences[0].Controller).To(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, s)).To(Succeed())
+	})
+
+	It("leaves a silence with the generated name it doesn't control alone", func() {
+		const name = "namespace-silence-conflict"
+		create(name)
+
+		matchers := monitoringv1alpha1.Matchers{{Name: "alertname", Value: "Mine", MatchType: monitoringv1alpha1.MatchEqual}}
+		Expect(k8sClient.Create(ctx, &monitoringv1alpha1.Silence{
+			ObjectMeta: metav1.ObjectMeta{Namespace: name, Name: NamespaceSilenceName},
+			Spec:       monitoringv1alpha1.SilenceSpec{Comment: "mine", Matchers: matchers},
+		})).To(Succeed())
+
+		Expect(reconcileNamespace(name)).To(Succeed())
+
+		s := getSilence(name)
+		Expect(s.Spec.Matchers).To(Equal(matchers))
+		Expect(s.OwnerReferences).To(BeEmpty())
+
+		Expect(k8sClient.Delete(ctx, s)).To(Succeed())
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// DefaultNodeMatchersTemplate silences alerts of the node by its name and, separately, by its internal IP.
+const DefaultNodeMatchersTemplate = `[` +
+	`[{"name": "node", "value": {{ .Name | regexQuote | toJson }}}]` +
+	`{{ with .InternalIP }},[{"name": "instance", "value": {{ printf "%s(:.*)?" (regexQuote .) | toJson }}}]{{ end }}` +
+	`]`
+
+// NodeReconciler keeps a Silence for nodes under maintenance: cordoned nodes and
+// nodes carrying the maintenance taint or annotation.
+type NodeReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Namespace is where the node silences are created.
+	Namespace string
+	// MaintenanceTaint is the taint key marking the node under maintenance, ignored if empty.
+	MaintenanceTaint string
+	// MaintenanceAnnotation is the annotation marking the node under maintenance, ignored if empty.
+	MaintenanceAnnotation string
+	// MatchersTemplate renders a JSON list of matcher lists, see NodeMatchersData for the available fields.
+	// Alertmanager matchers are AND-ed, so every matcher list gets its own Silence.
+	MatchersTemplate *template.Template
+}
+
+// NodeMatchersData is passed to the node matchers template.
+type NodeMatchersData struct {
+	Name       string
+	InternalIP string
+	Labels     map[string]string
+}
+
+// ParseNodeMatchersTemplate parses the template used to render node silence matchers.
+// Besides the standard functions, regexQuote escapes regex metacharacters
+// and toJson encodes the value as JSON, e.g. to quote a string.
+func ParseNodeMatchersTemplate(text string) (*template.Template, error) {
+	return template.New("node-matchers").
+		Funcs(template.FuncMap{
+			"regexQuote": regexp.QuoteMeta,
+			"toJson": func(v any) (string, error) {
+				b, err := json.Marshal(v)
+
+				return string(b), err
+			},
+		}).
+		Option("missingkey=error").
+		Parse(text)
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+// Reconcile creates the node silence while the node is under maintenance and deletes it afterwards.
+func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var matcherSets []monitoringv1alpha1.Matchers
+
+	if r.underMaintenance(node) && node.DeletionTimestamp.IsZero() {
+		var err error
+
+		matcherSets, err = r.renderMatchers(node)
+		if err != nil {
+			log.Error(err, "unable to render node silence matchers")
+
+			return ctrl.Result{}, nil
+		}
+	}
+
+	keep := map[string]bool{}
+
+	for i, matchers := range matcherSets {
+		s := &monitoringv1alpha1.Silence{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("node-%s-maintenance-%d", node.Name, i),
+				Namespace: r.Namespace,
+			},
+		}
+
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, s, func() error {
+			if err := ensureControlledBy(s, node); err != nil {
+				return err
+			}
+
+			metav1.SetMetaDataLabel(&s.ObjectMeta, monitoringv1alpha1.NodeLabel, node.Name)
+
+			s.Spec.Comment = fmt.Sprintf("Maintenance of node %s", node.Name)
+			s.Spec.Matchers = matchers
+
+			return controllerutil.SetControllerReference(node, s, r.Scheme)
+		})
+		if errors.Is(err, errNotControlled) {
+			log.Error(err, "conflicting node silence, leaving it alone", "silence", s.Name)
+
+			continue
+		}
+
+		if err != nil {
+			log.Error(err, "unable to create or update node silence", "silence", s.Name)
+
+			return ctrl.Result{}, err
+		}
+
+		if op != controllerutil.OperationResultNone {
+			log.Info("node is under maintenance, silence applied", "silence", s.Name, "operation", op)
+		}
+
+		keep[s.Name] = true
+	}
+
+	return ctrl.Result{}, r.deleteSilences(ctx, node, keep)
+}
+
+func (r *NodeReconciler) underMaintenance(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+
+	if r.MaintenanceAnnotation != "" {
+		if _, ok := node.Annotations[r.MaintenanceAnnotation]; ok {
+			return true
+		}
+	}
+
+	if r.MaintenanceTaint != "" {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == r.MaintenanceTaint {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *NodeReconciler) renderMatchers(node *corev1.Node) ([]monitoringv1alpha1.Matchers, error) {
+	data := NodeMatchersData{
+		Name:   node.Name,
+		Labels: node.Labels,
+	}
+
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			data.InternalIP = address.Address
+
+			break
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := r.MatchersTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+
+	matcherSets := []monitoringv1alpha1.Matchers{}
+	if err := json.Unmarshal(buf.Bytes(), &matcherSets); err != nil {
+		return nil, err
+	}
+
+	return matcherSets, nil
+}
+
+// deleteSilences deletes the silences controlled by the given node, except the ones to keep.
+func (r *NodeReconciler) deleteSilences(ctx context.Context, node *corev1.Node, keep map[string]bool) error {
+	silences := &monitoringv1alpha1.SilenceList{}
+	if err := r.List(ctx, silences,
+		client.InNamespace(r.Namespace),
+		client.MatchingLabels{monitoringv1alpha1.NodeLabel: node.Name},
+	); err != nil {
+		return err
+	}
+
+	for i := range silences.Items {
+		s := &silences.Items[i]
+
+		if keep[s.Name] || !metav1.IsControlledBy(s, node) || !s.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info("deleting node silence", "silence", s.Name)
+
+		if err := r.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		Owns(&monitoringv1alpha1.Silence{}).
+		Named("node").
+		Complete(r)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestParseNodeMatchersTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "default", text: DefaultNodeMatchersTemplate},
+		{name: "custom functions", text: `[[{"name": "node", "value": {{ .Name | regexQuote | toJson }}}]]`},
+		{name: "unclosed action", text: `[[{"name": "node", "value": {{ .Name }]]`, wantErr: true},
+		{name: "unknown function", text: `[[{"name": "node", "value": {{ .Name | quote }}}]]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseNodeMatchersTemplate(tt.text); (err != nil) != tt.wantErr {
+				t.Fatalf("ParseNodeMatchersTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodeRenderMatchers(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1.example.com",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "eu-1a"},
+		},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeHostName, Address: "node-1"},
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		node     *corev1.Node
+		want     []monitoringv1alpha1.Matchers
+		wantErr  bool
+	}{
+		{
+			name:     "default",
+			template: DefaultNodeMatchersTemplate,
+			node:     node,
+			want: []monitoringv1alpha1.Matchers{
+				{{Name: "node", Value: `node-1\.example\.com`}},
+				{{Name: "instance", Value: `10\.0\.0\.1(:.*)?`}},
+			},
+		},
+		{
+			name:     "default without internal IP",
+			template: DefaultNodeMatchersTemplate,
+			node:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+			want:     []monitoringv1alpha1.Matchers{{{Name: "node", Value: `node-2`}}},
+		},
+		{
+			name:     "labels",
+			template: `[[{"name": "zone", "value": {{ index .Labels "topology.kubernetes.io/zone" | toJson }}, "matchType": "="}]]`,
+			node:     node,
+			want: []monitoringv1alpha1.Matchers{
+				{{Name: "zone", Value: "eu-1a", MatchType: monitoringv1alpha1.MatchEqual}},
+			},
+		},
+		{
+			name:     "missing label",
+			template: `[[{"name": "rack", "value": {{ .Labels.rack | toJson }}}]]`,
+			node:     node,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid JSON",
+			template: `[{"name": "node", "value": {{ .Name }}}]`,
+			node:     node,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseNodeMatchersTemplate(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &NodeReconciler{MatchersTemplate: tmpl}
+
+			got, err := r.renderMatchers(tt.node)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderMatchers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("renderMatchers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeRenderMatchersRegexQuoting(t *testing.T) {
+	tmpl, err := ParseNodeMatchersTemplate(DefaultNodeMatchersTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &NodeReconciler{MatchersTemplate: tmpl}
+
+	sets, err := r.renderMatchers(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1.example.com"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Matchers without matchType are regex matchers, anchored like alertmanager does
+	matches := func(m monitoringv1alpha1.Matcher, value string) bool {
+		return regexp.MustCompile("^(?:" + m.Value + ")$").MatchString(value)
+	}
+
+	tests := []struct {
+		matcher monitoringv1alpha1.Matcher
+		value   string
+		want    bool
+	}{
+		{matcher: sets[0][0], value: "node-1.example.com", want: true},
+		{matcher: sets[0][0], value: "node-1xexample.com", want: false},
+		{matcher: sets[1][0], value: "10.0.0.1", want: true},
+		{matcher: sets[1][0], value: "10.0.0.1:9100", want: true},
+		{matcher: sets[1][0], value: "10.0.0.10:9100", want: false},
+		{matcher: sets[1][0], value: "10x0x0x1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.matcher.Name+"="+tt.value, func(t *testing.T) {
+			if err := tt.matcher.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := matches(tt.matcher, tt.value); got != tt.want {
+				t.Errorf("%s matches %q = %t, want %t", tt.matcher, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeUnderMaintenance(t *testing.T) {
+	r := &NodeReconciler{
+		MaintenanceTaint:      "example.com/maintenance",
+		MaintenanceAnnotation: "example.com/maintenance",
+	}
+
+	tests := []struct {
+		name       string
+		reconciler *NodeReconciler
+		node       corev1.Node
+		want       bool
+	}{
+		{
+			name:       "schedulable",
+			reconciler: r,
+		},
+		{
+			name:       "cordoned",
+			reconciler: r,
+			node:       corev1.Node{Spec: corev1.NodeSpec{Unschedulable: true}},
+			want:       true,
+		},
+		{
+			name:       "annotated",
+			reconciler: r,
+			node:       corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"example.com/maintenance": ""}}},
+			want:       true,
+		},
+		{
+			name:       "tainted",
+			reconciler: r,
+			node: corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule},
+			}}},
+			want: true,
+		},
+		{
+			name:       "other taint",
+			reconciler: r,
+			node: corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+			}}},
+		},
+		{
+			name:       "annotation and taint not configured",
+			reconciler: &NodeReconciler{},
+			node: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"example.com/maintenance": ""}},
+				Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+					{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reconciler.underMaintenance(&tt.node); got != tt.want {
+				t.Errorf("underMaintenance() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// errNotControlled is returned when a silence with a generated name exists but isn't controlled by the object
+// it is generated for, e.g. because a user created it. Such silences are never taken over.
+var errNotControlled = errors.New("silence exists and is not controlled by")
+
+// ensureControlledBy fails with errNotControlled if the existing silence is not controlled by the owner.
+// New silences, which have no resource version yet, pass.
+func ensureControlledBy(s *monitoringv1alpha1.Silence, owner metav1.Object) error {
+	if s.ResourceVersion == "" || metav1.IsControlledBy(s, owner) {
+		return nil
+	}
+
+	return fmt.Errorf("%w %s", errNotControlled, owner.GetName())
+}
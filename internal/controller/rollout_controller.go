@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+// RolloutReconciler keeps a Silence for annotated workloads while their rollout is in progress.
+// One reconciler is registered per workload kind.
+type RolloutReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Object is the watched workload kind: Deployment, StatefulSet or DaemonSet.
+	Object client.Object
+	// GracePeriod is how long the silence is kept after the rollout completed.
+	GracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch
+
+// Reconcile creates the rollout silence while the workload is rolling out and deletes it afterwards.
+func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	obj, ok := r.Object.DeepCopyObject().(client.Object)
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("unsupported workload type %T", r.Object)
+	}
+
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	silenceKey := types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      fmt.Sprintf("%s-%s-rollout", strings.ToLower(gvk.Kind), obj.GetName()),
+	}
+
+	value, annotated := obj.GetAnnotations()[monitoringv1alpha1.RolloutMatchersAnnotation]
+	if !annotated || !obj.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.deleteSilence(ctx, obj, silenceKey)
+	}
+
+	if !rolloutInProgress(obj) {
+		return r.completeRollout(ctx, obj, silenceKey)
+	}
+
+	matchers := monitoringv1alpha1.Matchers{}
+	if err := json.Unmarshal([]byte(value), &matchers); err != nil {
+		log.Error(err, "unable to parse rollout matchers", "annotation", monitoringv1alpha1.RolloutMatchersAnnotation)
+
+		return ctrl.Result{}, nil
+	}
+
+	s := &monitoringv1alpha1.Silence{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      silenceKey.Name,
+			Namespace: silenceKey.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, s, func() error {
+		if err := ensureControlledBy(s, obj); err != nil {
+			return err
+		}
+
+		delete(s.Annotations, monitoringv1alpha1.RolloutCompletedAnnotation)
+
+		s.Spec.Comment = fmt.Sprintf("Rollout of %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+		s.Spec.Matchers = matchers
+
+		return controllerutil.SetControllerReference(obj, s, r.Scheme)
+	})
+	if errors.Is(err, errNotControlled) {
+		log.Error(err, "conflicting rollout silence, leaving it alone", "silence", silenceKey.Name)
+
+		return ctrl.Result{}, nil
+	}
+
+	if err != nil {
+		log.Error(err, "unable to create or update rollout silence", "silence", silenceKey.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	if op != controllerutil.OperationResultNone {
+		log.Info("rollout in progress, silence applied", "silence", silenceKey.Name, "operation", op)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// completeRollout marks the rollout silence as completed and deletes it after the grace period.
+func (r *RolloutReconciler) completeRollout(
+	ctx context.Context, owner client.Object, key types.NamespacedName,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	s := &monitoringv1alpha1.Silence{}
+	if err := r.Get(ctx, key, s); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(s, owner) {
+		return ctrl.Result{}, nil
+	}
+
+	completedAt, err := time.Parse(time.RFC3339, s.Annotations[monitoringv1alpha1.RolloutCompletedAnnotation])
+	if err != nil {
+		log.Info("rollout completed", "silence", key.Name, "grace_period", r.GracePeriod)
+
+		patch := client.MergeFrom(s.DeepCopy())
+		metav1.SetMetaDataAnnotation(&s.ObjectMeta, monitoringv1alpha1.RolloutCompletedAnnotation,
+			time.Now().UTC().Format(time.RFC3339))
+
+		return ctrl.Result{RequeueAfter: r.GracePeriod}, r.Patch(ctx, s, patch)
+	}
+
+	if remaining := time.Until(completedAt.Add(r.GracePeriod)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return ctrl.Result{}, r.deleteSilence(ctx, owner, key)
+}
+
+// deleteSilence deletes the rollout silence if it is controlled by the given workload.
+func (r *RolloutReconciler) deleteSilence(ctx context.Context, owner client.Object, key types.NamespacedName) error {
+	s := &monitoringv1alpha1.Silence{}
+	if err := r.Get(ctx, key, s); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(s, owner) || !s.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("deleting rollout silence", "silence", key.Name)
+
+	if err := r.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// rolloutInProgress reports whether the workload has not finished rolling out yet
+// or still has unavailable replicas.
+func rolloutInProgress(obj client.Object) bool {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		return w.Status.ObservedGeneration != w.Generation ||
+			w.Status.UnavailableReplicas > 0 ||
+			w.Status.UpdatedReplicas < ptr.Deref(w.Spec.Replicas, 1)
+	case *appsv1.StatefulSet:
+		replicas := ptr.Deref(w.Spec.Replicas, 1)
+
+		if w.Status.ObservedGeneration != w.Generation || w.Status.ReadyReplicas < replicas {
+			return true
+		}
+
+		// The revisions only converge once a rolling update replaced all pods. Pods of an OnDelete
+		// StatefulSet and pods below a partition are not updated, so the updated replicas tell the progress.
+		partition := int32(0)
+		if rolling := w.Spec.UpdateStrategy.RollingUpdate; rolling != nil {
+			partition = min(max(ptr.Deref(rolling.Partition, 0), 0), replicas)
+		}
+
+		if w.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType || partition > 0 {
+			return w.Status.UpdatedReplicas < replicas-partition
+		}
+
+		return w.Status.UpdateRevision != w.Status.CurrentRevision
+	case *appsv1.DaemonSet:
+		return w.Status.ObservedGeneration != w.Generation ||
+			w.Status.NumberUnavailable > 0 ||
+			w.Status.UpdatedNumberScheduled < w.Status.DesiredNumberScheduled
+	}
+
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	gvk, err := apiutil.GVKForObject(r.Object, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(r.Object).
+		Owns(&monitoringv1alpha1.Silence{}).
+		Named("rollout-" + strings.ToLower(gvk.Kind)).
+		Complete(r)
+}
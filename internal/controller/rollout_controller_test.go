@@ -0,0 +1,340 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+func TestRolloutInProgress(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "app", Generation: 2}
+
+	deployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: meta, Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)}, Status: status}
+	}
+	statefulSet := func(status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: meta, Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)}, Status: status}
+	}
+	statefulSetWith := func(strategy appsv1.StatefulSetUpdateStrategy, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		s := statefulSet(status)
+		s.Spec.UpdateStrategy = strategy
+
+		return s
+	}
+	partitioned := func(partition int32) appsv1.StatefulSetUpdateStrategy {
+		return appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(partition)},
+		}
+	}
+	daemonSet := func(status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{ObjectMeta: meta, Status: status}
+	}
+
+	tests := []struct {
+		name string
+		obj  client.Object
+		want bool
+	}{
+		{
+			name: "deployment rolled out",
+			obj:  deployment(appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 3}),
+		},
+		{
+			name: "deployment spec not observed",
+			obj:  deployment(appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 3}),
+			want: true,
+		},
+		{
+			name: "deployment with unavailable replicas",
+			obj:  deployment(appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 3, UnavailableReplicas: 1}),
+			want: true,
+		},
+		{
+			name: "deployment with outdated replicas",
+			obj:  deployment(appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2}),
+			want: true,
+		},
+		{
+			name: "deployment with default replicas",
+			obj: &appsv1.Deployment{ObjectMeta: meta, Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2, UpdatedReplicas: 1,
+			}},
+		},
+		{
+			name: "statefulset rolled out",
+			obj: statefulSet(appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 3, CurrentRevision: "app-1", UpdateRevision: "app-1",
+			}),
+		},
+		{
+			name: "statefulset spec not observed",
+			obj: statefulSet(appsv1.StatefulSetStatus{
+				ObservedGeneration: 1, ReadyReplicas: 3, CurrentRevision: "app-1", UpdateRevision: "app-1",
+			}),
+			want: true,
+		},
+		{
+			name: "statefulset updating revision",
+			obj: statefulSet(appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 3, CurrentRevision: "app-1", UpdateRevision: "app-2",
+			}),
+			want: true,
+		},
+		{
+			name: "statefulset with unready replicas",
+			obj: statefulSet(appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 2, CurrentRevision: "app-1", UpdateRevision: "app-1",
+			}),
+			want: true,
+		},
+		{
+			name: "statefulset on delete with outdated pods",
+			obj: statefulSetWith(appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+				appsv1.StatefulSetStatus{
+					ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1,
+					CurrentRevision: "app-1", UpdateRevision: "app-2",
+				}),
+			want: true,
+		},
+		{
+			name: "statefulset on delete with all pods updated",
+			obj: statefulSetWith(appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+				appsv1.StatefulSetStatus{
+					ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 3,
+					CurrentRevision: "app-1", UpdateRevision: "app-2",
+				}),
+		},
+		{
+			name: "statefulset partition updating",
+			obj: statefulSetWith(partitioned(1), appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1,
+				CurrentRevision: "app-1", UpdateRevision: "app-2",
+			}),
+			want: true,
+		},
+		{
+			name: "statefulset partition updated",
+			obj: statefulSetWith(partitioned(1), appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 2,
+				CurrentRevision: "app-1", UpdateRevision: "app-2",
+			}),
+		},
+		{
+			name: "statefulset partition above replicas",
+			obj: statefulSetWith(partitioned(5), appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 3, CurrentRevision: "app-1", UpdateRevision: "app-2",
+			}),
+		},
+		{
+			name: "statefulset partition with unready replicas",
+			obj: statefulSetWith(partitioned(1), appsv1.StatefulSetStatus{
+				ObservedGeneration: 2, ReadyReplicas: 2, UpdatedReplicas: 2,
+				CurrentRevision: "app-1", UpdateRevision: "app-2",
+			}),
+			want: true,
+		},
+		{
+			name: "daemonset rolled out",
+			obj: daemonSet(appsv1.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3,
+			}),
+		},
+		{
+			name: "daemonset spec not observed",
+			obj: daemonSet(appsv1.DaemonSetStatus{
+				ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3,
+			}),
+			want: true,
+		},
+		{
+			name: "daemonset with unavailable pods",
+			obj: daemonSet(appsv1.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberUnavailable: 1,
+			}),
+			want: true,
+		},
+		{
+			name: "daemonset with outdated pods",
+			obj: daemonSet(appsv1.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2,
+			}),
+			want: true,
+		},
+		{
+			name: "unsupported kind",
+			obj:  &appsv1.ReplicaSet{ObjectMeta: meta},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rolloutInProgress(tt.obj); got != tt.want {
+				t.Errorf("rolloutInProgress() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+var _ = Describe("Rollout Controller", func() {
+	const namespace = "default"
+
+	var reconciler *RolloutReconciler
+
+	// create creates a single replica deployment annotated with the rollout matchers
+	create := func(name string) *appsv1.Deployment {
+		labels := map[string]string{"app": name}
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Annotations: map[string]string{
+					monitoringv1alpha1.RolloutMatchersAnnotation: `[{"name":"app","value":"` + name + `","matchType":"="}]`,
+				},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](1),
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app"}}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, d)).To(Succeed())
+
+		return d
+	}
+
+	// setRolledOut updates the deployment status as the deployment controller would
+	setRolledOut := func(d *appsv1.Deployment, rolledOut bool) {
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(d), d)).To(Succeed())
+		d.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: d.Generation,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+		}
+		if !rolledOut {
+			d.Status.UpdatedReplicas = 0
+			d.Status.UnavailableReplicas = 1
+		}
+		Expect(k8sClient.Status().Update(ctx, d)).To(Succeed())
+	}
+
+	reconcileDeployment := func(d *appsv1.Deployment) ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(d)})
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	silenceKey := func(d *appsv1.Deployment) types.NamespacedName {
+		return types.NamespacedName{Namespace: namespace, Name: "deployment-" + d.Name + "-rollout"}
+	}
+
+	getSilence := func(d *appsv1.Deployment) *monitoringv1alpha1.Silence {
+		s := &monitoringv1alpha1.Silence{}
+		Expect(k8sClient.Get(ctx, silenceKey(d), s)).To(Succeed())
+
+		return s
+	}
+
+	BeforeEach(func() {
+		reconciler = &RolloutReconciler{
+			Client:      k8sClient,
+			Scheme:      k8sClient.Scheme(),
+			Object:      &appsv1.Deployment{},
+			GracePeriod: time.Hour,
+		}
+	})
+
+	It("creates a silence controlled by the deployment when a rollout starts", func() {
+		d := create("rollout-started")
+		setRolledOut(d, false)
+		Expect(reconcileDeployment(d)).To(Equal(ctrl.Result{}))
+
+		s := getSilence(d)
+		Expect(s.Spec.Matchers).To(Equal(monitoringv1alpha1.Matchers{
+			{Name: "app", Value: d.Name, MatchType: monitoringv1alpha1.MatchEqual},
+		}))
+		Expect(metav1.IsControlledBy(s, d)).To(BeTrue())
+		Expect(s.Annotations).NotTo(HaveKey(monitoringv1alpha1.RolloutCompletedAnnotation))
+
+		Expect(k8sClient.Delete(ctx, s)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, d)).To(Succeed())
+	})
+
+	It("marks the silence completed and deletes it after the grace period", func() {
+		d := create("rollout-completed")
+		setRolledOut(d, false)
+		reconcileDeployment(d)
+
+		setRolledOut(d, true)
+		Expect(reconcileDeployment(d)).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+		s := getSilence(d)
+		Expect(s.Annotations).To(HaveKey(monitoringv1alpha1.RolloutCompletedAnnotation))
+
+		By("keeping the silence within the grace period")
+		Expect(reconcileDeployment(d).RequeueAfter).To(BeNumerically(">", 0))
+		getSilence(d)
+
+		By("deleting the silence once the grace period passed")
+		s.Annotations[monitoringv1alpha1.RolloutCompletedAnnotation] = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		Expect(k8sClient.Update(ctx, s)).To(Succeed())
+		Expect(reconcileDeployment(d)).To(Equal(ctrl.Result{}))
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, silenceKey(d), s))).To(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, d)).To(Succeed())
+	})
+
+	It("keeps the silence when a rollout restarts within the grace period", func() {
+		d := create("rollout-restarted")
+		setRolledOut(d, false)
+		reconcileDeployment(d)
+		setRolledOut(d, true)
+		reconcileDeployment(d)
+		Expect(getSilence(d).Annotations).To(HaveKey(monitoringv1alpha1.RolloutCompletedAnnotation))
+
+		setRolledOut(d, false)
+		Expect(reconcileDeployment(d)).To(Equal(ctrl.Result{}))
+
+		s := getSilence(d)
+		Expect(s.Annotations).NotTo(HaveKey(monitoringv1alpha1.RolloutCompletedAnnotation))
+		Expect(s.DeletionTimestamp).To(BeNil())
+
+		Expect(k8sClient.Delete(ctx, s)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, d)).To(Succeed())
+	})
+})
@@ -18,20 +18,44 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
 	"github.com/prometheus/alertmanager/api/v2/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
 	"github.com/silence-operator/silence-operator/internal/alertmanager"
 )
 
+const (
+	// templateRefField indexes silences by the name of the referenced SilenceTemplate.
+	templateRefField = ".spec.templateRef.name"
+
+	// maxMutedAlertSamples bounds the list of muted alerts kept in the status.
+	maxMutedAlertSamples = 10
+)
+
 // SilenceReconciler reconciles a Silence object
 type SilenceReconciler struct {
 	client.Client
@@ -40,13 +64,44 @@ type SilenceReconciler struct {
 	AlertManager *alertmanager.AlertManager
 	Interval     time.Duration
 
+	// ExtendThreshold is the fraction of the silence duration left when the silence gets extended.
+	ExtendThreshold float64
+
 	GetSilenceAttempts int
 	GetSilenceInterval time.Duration
+
+	// MatchesNothingAfter is how long a silence may mute no alert before the MatchesNothing condition is set.
+	// Zero disables the condition.
+	MatchesNothingAfter time.Duration
+
+	// DeletionTimeout is how long expiring the alertmanager silence of a deleted object is retried.
+	// Afterwards the finalizer is removed anyway and a Warning event is recorded.
+	DeletionTimeout time.Duration
+
+	// DryRun previews all silences as if they had spec.dryRun set.
+	DryRun bool
+
+	Recorder record.EventRecorder
+
+	// Recover receives the silences to reconcile right away after alertmanager lost them.
+	Recover <-chan event.GenericEvent
+
+	// unrecorded holds the replacement silences whose status patch failed, by object, so the next
+	// reconciliation records them instead of replacing the recorded silence again.
+	unrecorded sync.Map
+}
+
+// unrecordedSilence is an alertmanager silence which replaced recordedID but isn't recorded yet.
+type unrecordedSilence struct {
+	recordedID string
+	id         string
 }
 
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silences,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silences/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silences/finalizers,verbs=update
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silencetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -73,23 +128,17 @@ func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	// Handle object deletion
 	if !obj.DeletionTimestamp.IsZero() {
-		if obj.Status.AlertManagerID != "" {
-			log.Info("deleting alertmanager silence", "am_id", obj.Status.AlertManagerID)
+		if err := r.expireOnDeletion(ctx, obj); err != nil {
+			reconciliationCompleted = false
 
-			err := r.AlertManager.DeleteSilence(obj.Status.AlertManagerID)
-			if err != nil {
-				reconciliationCompleted = false
-				log.Error(err, "unable to delete silence in alertmanager", "am_id", obj.Status.AlertManagerID)
-			}
+			// Retried with the backoff of the controller
+			return ctrl.Result{}, err
 		}
 
-		if removed := controllerutil.RemoveFinalizer(obj, monitoringv1alpha1.SilenceFinalizer); removed {
-			err := r.Update(ctx, obj)
-			if err != nil {
-				reconciliationCompleted = false
+		if _, err := removeFinalizer(ctx, r.Client, obj, monitoringv1alpha1.SilenceFinalizer); err != nil {
+			reconciliationCompleted = false
 
-				log.Error(err, "unable to remove finalizer from silence")
-			}
+			log.Error(err, "unable to remove finalizer from silence")
 
 			return ctrl.Result{}, err
 		}
@@ -97,29 +146,86 @@ func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, nil
 	}
 
-	if finalizerAdded := controllerutil.AddFinalizer(obj, monitoringv1alpha1.SilenceFinalizer); finalizerAdded {
-		err := r.Update(ctx, obj)
-		if err != nil {
-			reconciliationCompleted = false
+	finalizerAdded, err := addFinalizer(ctx, r.Client, obj, monitoringv1alpha1.SilenceFinalizer)
+	if err != nil {
+		reconciliationCompleted = false
 
-			log.Error(err, "unable to add finalizer to silence")
+		log.Error(err, "unable to add finalizer to silence")
 
-			return ctrl.Result{RequeueAfter: r.Interval}, err
-		}
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
 
+	if finalizerAdded {
 		log.Info("successfully added finalizer to silence")
 
 		return ctrl.Result{RequeueAfter: r.Interval}, nil
 	}
 
 	if obj.Spec.Suspend {
-		log.Info("reconciliation is suspended")
+		return r.suspend(ctx, obj)
+	}
 
-		return ctrl.Result{}, nil
+	if expiresAt := obj.ExpiresAt(); expiresAt != nil && !time.Now().Before(*expiresAt) {
+		return r.expire(ctx, obj)
+	}
+
+	if ref := obj.Spec.FromAlert; ref != nil &&
+		(obj.Status.FromAlert == nil || !equality.Semantic.DeepEqual(obj.Status.FromAlert.Reference, *ref)) {
+		return r.resolveAlert(ctx, obj)
+	}
+
+	matchers, err := r.matchers(ctx, obj)
+	if err != nil {
+		reconciliationCompleted = false
+
+		log.Error(err, "unable to build silence matchers")
+
+		condition := metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionTemplateRendered,
+			Status:             metav1.ConditionFalse,
+			Reason:             "RenderFailed",
+			Message:            err.Error(),
+			ObservedGeneration: obj.Generation,
+		}
+
+		var invalidErr *invalidMatchersError
+		if errors.As(err, &invalidErr) {
+			condition.Type = monitoringv1alpha1.ConditionMatchersValid
+			condition.Reason = "InvalidMatchers"
+		}
+
+		meta.SetStatusCondition(&obj.Status.Conditions, condition)
+
+		if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+			log.Error(err, "unable to update status")
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	if obj.Spec.DryRun || r.DryRun {
+		return r.preview(ctx, obj, matchers)
 	}
 
 	var startsAt *strfmt.DateTime
 
+	// The silence recorded in the status before it is looked up and possibly adopted or replaced
+	recordedID := obj.Status.AlertManagerID
+
+	if id := obj.Annotations[monitoringv1alpha1.AdoptSilenceAnnotation]; recordedID == "" && id != "" {
+		log.Info("adopting alertmanager silence", "am_id", id)
+		obj.Status.AlertManagerID = id
+	}
+
+	if v, ok := r.unrecorded.LoadAndDelete(req.NamespacedName); ok {
+		if u := v.(unrecordedSilence); u.recordedID == recordedID {
+			log.Info("recording replacement alertmanager silence", "am_id", u.id)
+			obj.Status.AlertManagerID = u.id
+		}
+	}
+
 	if obj.Status.AlertManagerID == "" {
 		log.Info("silence is not created yet, creating")
 	} else {
@@ -142,6 +248,13 @@ func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 			response, err = r.AlertManager.GetSilence(obj.Status.AlertManagerID)
 			if err != nil {
+				// A silence lost by alertmanager won't show up on another replica either
+				if r.AlertManager.Lost(obj.Status.AlertManagerID) {
+					attempt = r.GetSilenceAttempts + 1
+
+					continue
+				}
+
 				attempt++
 				time.Sleep(r.GetSilenceInterval)
 
@@ -160,22 +273,39 @@ func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			} else {
 				if obj.Generation != obj.Status.LastAppliedGeneration {
 					log.Info("updating alertmanager silence", "am_id", obj.Status.AlertManagerID)
+				} else if !alertmanager.MatchersEqual(s.Matchers, matchers) {
+					log.Info("matchers changed, updating alertmanager silence", "am_id", obj.Status.AlertManagerID)
 				} else {
-					// Extend silence if three or less reconciliations left
-					deadline := time.Now().Add(r.Interval * 3)
+					// Extend silence once only a fraction of its duration is left
+					extendAt := r.extendAt(obj, time.Time(*s.EndsAt))
 
-					if deadline.Before(time.Time(*s.EndsAt)) {
-						log.Info("no need for reconciliation")
+					if time.Now().Before(extendAt) {
+						log.Info("no need for reconciliation", "extend_at", extendAt)
 						reconciliationCompleted = false
 
-						return ctrl.Result{RequeueAfter: r.Interval}, nil
+						statusChanged := r.updateMutedAlerts(ctx, obj, matchers)
+
+						if recordMatchers(obj, matchers) || !obj.Status.Active {
+							obj.Status.Active = true
+							statusChanged = true
+						}
+
+						if statusChanged || obj.Status.AlertManagerID != recordedID {
+							if err := r.patchStatus(ctx, obj, recordedID); err != nil {
+								log.Error(err, "unable to update status")
+
+								return ctrl.Result{RequeueAfter: r.Interval}, err
+							}
+						}
+
+						return ctrl.Result{RequeueAfter: r.requeueAfter(extendAt)}, nil
 					}
 				}
 			}
 		}
 	}
 
-	id, err := r.AlertManager.UpsertSilence(ctx, obj, startsAt)
+	id, err := r.AlertManager.UpsertSilence(ctx, obj, matchers, startsAt)
 	if err != nil {
 		reconciliationCompleted = false
 
@@ -184,39 +314,610 @@ func (r *SilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{RequeueAfter: r.Interval}, err
 	}
 
-	if obj.Status.AlertManagerID == id {
-		return ctrl.Result{RequeueAfter: r.Interval}, err
+	result := ctrl.Result{
+		RequeueAfter: r.requeueAfter(r.extendAt(obj, r.AlertManager.EndsAt(obj, time.Now()))),
+	}
+
+	conditionsChanged := false
+
+	if obj.ExpiresAt() != nil {
+		conditionsChanged = meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionExpired,
+			Status:             metav1.ConditionFalse,
+			Reason:             "WithinLifetime",
+			Message:            "Silence has not reached its maximum lifetime or endsAt yet",
+			ObservedGeneration: obj.Generation,
+		})
+	} else {
+		conditionsChanged = meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionExpired)
+	}
+
+	if obj.Spec.TemplateRef != nil {
+		conditionsChanged = meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionTemplateRendered,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Rendered",
+			Message:            "Silence template rendered successfully",
+			ObservedGeneration: obj.Generation,
+		}) || conditionsChanged
+	} else {
+		conditionsChanged = meta.RemoveStatusCondition(&obj.Status.Conditions,
+			monitoringv1alpha1.ConditionTemplateRendered) || conditionsChanged
+	}
+
+	conditionsChanged = meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionMatchersValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            "All matchers are valid",
+		ObservedGeneration: obj.Generation,
+	}) || conditionsChanged
+
+	if obj.Spec.FromAlert == nil && obj.Status.FromAlert != nil {
+		obj.Status.FromAlert = nil
+		meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionAlertResolved)
+		conditionsChanged = true
+	}
+
+	conditionsChanged = meta.RemoveStatusCondition(&obj.Status.Conditions,
+		monitoringv1alpha1.ConditionSuspended) || conditionsChanged
+
+	if !obj.Status.Active {
+		obj.Status.Active = true
+		conditionsChanged = true
+	}
+
+	if meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionDryRun) {
+		obj.Status.MatchingAlerts = 0
+		obj.Status.MatchingAlertSamples = nil
+		conditionsChanged = true
+	}
+
+	if recordMatchers(obj, matchers) {
+		conditionsChanged = true
+	}
+
+	idChanged := obj.Status.AlertManagerID != id
+
+	if idChanged {
+		// Alerts muted by the previous silence don't tell anything about the new one
+		obj.Status.MutedAlerts = 0
+		obj.Status.MutedAlertSamples = nil
+		obj.Status.MatchingNothingSince = nil
+	} else {
+		conditionsChanged = r.updateMutedAlerts(ctx, obj, matchers) || conditionsChanged
+	}
+
+	if !idChanged && !conditionsChanged && obj.Status.LastAppliedGeneration == obj.Generation {
+		return result, err
 	}
 
 	log.Info("updating status of the silence object")
 
+	// Only a silence created in this pass may be rolled back. An adopted silence isn't ours, and when alertmanager
+	// replaced the recorded silence by a new one, it already expired the recorded one.
+	created := recordedID == "" && obj.Status.AlertManagerID == ""
+
 	obj.Status.AlertManagerID = id
 	obj.Status.LastAppliedGeneration = obj.Generation
 
-	err = r.Status().Update(ctx, obj)
+	err = r.patchStatus(ctx, obj, recordedID)
 	if err != nil {
 		reconciliationCompleted = false
 
 		log.Error(err, "unable to update status")
 
-		log.Info("cleaning up alertmanager silence")
+		switch {
+		case created:
+			log.Info("cleaning up alertmanager silence", "am_id", id)
 
-		err2 := r.AlertManager.DeleteSilence(id)
-		if err2 != nil {
-			log.Error(err2, "unable to delete alertmanager silence")
+			err2 := r.AlertManager.DeleteSilence(id)
+			if err2 != nil {
+				log.Error(err2, "unable to delete alertmanager silence")
+			}
+		case idChanged:
+			// Expiring the replacement would leave the alerts unmuted until the retry
+			log.Info("keeping the replacement alertmanager silence, recording it on retry", "am_id", id)
+			r.unrecorded.Store(req.NamespacedName, unrecordedSilence{recordedID: recordedID, id: id})
 		}
 
 		return ctrl.Result{RequeueAfter: r.Interval}, err
 	}
 
-	return ctrl.Result{RequeueAfter: r.Interval}, err
+	return result, err
+}
+
+// errSilenceReplaced is returned by patchStatus if another reconciliation, e.g. by a previous leader
+// which was still running, recorded a different alertmanager silence in the meantime.
+var errSilenceReplaced = errors.New("alertmanager silence was recorded concurrently")
+
+// patchStatus patches the status computed on obj. Conflicts are retried against the latest version
+// of the object, as long as it still records the alertmanager silence recordedID.
+func (r *SilenceReconciler) patchStatus(
+	ctx context.Context, obj *monitoringv1alpha1.Silence, recordedID string,
+) error {
+	status := obj.Status.DeepCopy()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &monitoringv1alpha1.Silence{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+
+		if latest.Status.AlertManagerID != recordedID {
+			return errSilenceReplaced
+		}
+
+		patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		latest.Status = *status
+
+		if err := r.Status().Patch(ctx, latest, patch); err != nil {
+			return err
+		}
+
+		obj.ResourceVersion = latest.ResourceVersion
+
+		return nil
+	})
+}
+
+// matchersSummary formats matchers like alertmanager does for display, e.g. `{alertname="Foo", env="prod"}`.
+func matchersSummary(matchers monitoringv1alpha1.Matchers) string {
+	return "{" + strings.Join(matchers.String(), ", ") + "}"
+}
+
+// recordMatchers records the applied matchers and their summary in the status and reports whether they changed.
+// The matchers are recorded with their matchType, also when they were given by the deprecated isEqual and isRegex.
+func recordMatchers(obj *monitoringv1alpha1.Silence, matchers monitoringv1alpha1.Matchers) bool {
+	applied := make(monitoringv1alpha1.Matchers, 0, len(matchers))
+	for _, m := range matchers {
+		applied = append(applied, monitoringv1alpha1.Matcher{
+			Name:      m.Name,
+			Value:     m.Value,
+			MatchType: monitoringv1alpha1.MatchType(m.Type().String()),
+		})
+	}
+
+	summary := matchersSummary(matchers)
+	if obj.Status.Matchers == summary && slices.Equal(obj.Status.AppliedMatchers, applied) {
+		return false
+	}
+
+	obj.Status.Matchers = summary
+	obj.Status.AppliedMatchers = applied
+
+	return true
+}
+
+// expireOnDeletion expires the alertmanager silence of a deleted object, unless its deletion policy is Orphan.
+// Errors are returned for retrying until the deletion timeout has passed, then the silence is given up.
+func (r *SilenceReconciler) expireOnDeletion(ctx context.Context, obj *monitoringv1alpha1.Silence) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	id := obj.Status.AlertManagerID
+	if id == "" {
+		return nil
+	}
+
+	if obj.Spec.DeletionPolicy == monitoringv1alpha1.DeletionPolicyOrphan {
+		log.Info("deletion policy is Orphan, leaving alertmanager silence", "am_id", id)
+
+		return nil
+	}
+
+	log.Info("deleting alertmanager silence", "am_id", id)
+
+	err := r.AlertManager.DeleteSilence(id)
+
+	var notFound *silence.DeleteSilenceNotFound
+	if err == nil || errors.As(err, &notFound) {
+		return nil
+	}
+
+	log.Error(err, "unable to delete silence in alertmanager", "am_id", id)
+
+	if time.Since(obj.DeletionTimestamp.Time) < r.DeletionTimeout {
+		return err
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, "ExpireFailed",
+			"Gave up expiring alertmanager silence %s after %s, it ends on its own: %v", id, r.DeletionTimeout, err)
+	}
+
+	return nil
+}
+
+// invalidMatchersError is returned by matchers if one of spec.matcherExpressions can't be parsed
+// or one of the resulting matchers is invalid.
+type invalidMatchersError struct {
+	err error
+}
+
+func (e *invalidMatchersError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidMatchersError) Unwrap() error {
+	return e.err
+}
+
+// matchers returns the spec matchers together with the parsed matcher expressions, the matchers derived
+// from the referenced alert and the matchers rendered from the referenced template.
+func (r *SilenceReconciler) matchers(ctx context.Context, obj *monitoringv1alpha1.Silence) (monitoringv1alpha1.Matchers, error) {
+	matchers := obj.Spec.Matchers.DeepCopy()
+
+	for _, expression := range obj.Spec.MatcherExpressions {
+		m, err := monitoringv1alpha1.ParseMatcher(expression)
+		if err != nil {
+			return nil, &invalidMatchersError{err: fmt.Errorf("matcher expression %q: %w", expression, err)}
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	if obj.Spec.FromAlert != nil && obj.Status.FromAlert != nil {
+		matchers = append(matchers, obj.Status.FromAlert.Matchers...)
+	}
+
+	if ref := obj.Spec.TemplateRef; ref != nil {
+		tmpl := &monitoringv1alpha1.SilenceTemplate{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: ref.Name}, tmpl); err != nil {
+			return nil, err
+		}
+
+		rendered, err := tmpl.Render(ref.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, rendered...)
+	}
+
+	if err := matchers.Validate(); err != nil {
+		return nil, &invalidMatchersError{err: err}
+	}
+
+	return matchers, nil
+}
+
+// resolveAlert looks up the alert referenced by spec.fromAlert and records the derived matchers in the status.
+// The status update triggers the next reconciliation, which applies the silence.
+func (r *SilenceReconciler) resolveAlert(ctx context.Context, obj *monitoringv1alpha1.Silence) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	ref := obj.Spec.FromAlert
+
+	found, err := r.AlertManager.FindAlert(ref)
+	if err != nil {
+		log.Error(err, "unable to find alert referenced by fromAlert")
+
+		reason := "LookupFailed"
+		if errors.Is(err, alertmanager.ErrAlertNotFound) {
+			reason = "AlertNotFound"
+		}
+
+		meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionAlertResolved,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            err.Error(),
+			ObservedGeneration: obj.Generation,
+		})
+
+		if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+			log.Error(err, "unable to update status")
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	log.Info("alert resolved", "fingerprint", *found.Fingerprint)
+
+	obj.Status.FromAlert = &monitoringv1alpha1.ResolvedAlert{
+		Reference:   *ref.DeepCopy(),
+		Fingerprint: *found.Fingerprint,
+		Matchers:    alertmanager.AlertMatchers(found.Labels, ref),
+	}
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionAlertResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Resolved",
+		Message:            fmt.Sprintf("Matchers derived from alert %s", *found.Fingerprint),
+		ObservedGeneration: obj.Generation,
+	})
+
+	if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+		log.Error(err, "unable to update status")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// silencesForTemplate enqueues all silences referencing the given template.
+func (r *SilenceReconciler) silencesForTemplate(ctx context.Context, tmpl client.Object) []reconcile.Request {
+	silences := &monitoringv1alpha1.SilenceList{}
+	if err := r.List(ctx, silences,
+		client.InNamespace(tmpl.GetNamespace()),
+		client.MatchingFields{templateRefField: tmpl.GetName()},
+	); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to list silences referencing template", "template", tmpl.GetName())
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(silences.Items))
+
+	for _, s := range silences.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&s)})
+	}
+
+	return requests
+}
+
+// updateMutedAlerts records the alerts muted by the alertmanager silence in the status
+// and reports whether the status changed.
+func (r *SilenceReconciler) updateMutedAlerts(
+	ctx context.Context, obj *monitoringv1alpha1.Silence, matchers monitoringv1alpha1.Matchers,
+) bool {
+	if obj.Status.AlertManagerID == "" {
+		return false
+	}
+
+	alerts, err := r.AlertManager.MutedAlerts(obj.Status.AlertManagerID, matchers)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to get muted alerts", "am_id", obj.Status.AlertManagerID)
+
+		return false
+	}
+
+	before := obj.Status.DeepCopy()
+
+	obj.Status.MutedAlerts = len(alerts)
+	obj.Status.MutedAlertSamples = alertSamples(alerts)
+
+	switch {
+	case len(alerts) > 0:
+		obj.Status.MatchingNothingSince = nil
+	case obj.Status.MatchingNothingSince == nil:
+		obj.Status.MatchingNothingSince = ptr.To(metav1.Now())
+	}
+
+	since := obj.Status.MatchingNothingSince
+
+	if r.MatchesNothingAfter > 0 && since != nil && time.Since(since.Time) >= r.MatchesNothingAfter {
+		meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionMatchesNothing,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoMutedAlerts",
+			Message:            fmt.Sprintf("Silence has not muted any alert since %s", since.UTC().Format(time.RFC3339)),
+			ObservedGeneration: obj.Generation,
+		})
+	} else {
+		meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionMatchesNothing)
+	}
+
+	return !equality.Semantic.DeepEqual(before, &obj.Status)
+}
+
+// preview reports the alerts the silence would mute without applying it to alertmanager.
+func (r *SilenceReconciler) preview(
+	ctx context.Context, obj *monitoringv1alpha1.Silence, matchers monitoringv1alpha1.Matchers,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	alerts, err := r.AlertManager.MatchingAlerts(matchers)
+	if err != nil {
+		log.Error(err, "unable to evaluate silence matchers")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	before := obj.Status.DeepCopy()
+
+	meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionSuspended)
+
+	obj.Status.MatchingAlerts = len(alerts)
+	obj.Status.MatchingAlertSamples = alertSamples(alerts)
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Preview",
+		Message:            fmt.Sprintf("Silence is not applied, it would mute %d alerts", len(alerts)),
+		ObservedGeneration: obj.Generation,
+	})
+
+	if !equality.Semantic.DeepEqual(before, &obj.Status) {
+		log.Info("dry run, silence is not applied", "matching_alerts", len(alerts))
+
+		if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+			log.Error(err, "unable to update status")
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// alertSamples returns a bounded list of the given alerts for the status.
+// The alerts are sorted, so the samples are stable between checks and the status is not updated needlessly.
+func alertSamples(alerts models.GettableAlerts) []monitoringv1alpha1.MutedAlert {
+	slices.SortFunc(alerts, func(a, b *models.GettableAlert) int {
+		return strings.Compare(*a.Fingerprint, *b.Fingerprint)
+	})
+
+	var samples []monitoringv1alpha1.MutedAlert
+
+	for _, a := range alerts[:min(len(alerts), maxMutedAlertSamples)] {
+		samples = append(samples, monitoringv1alpha1.MutedAlert{
+			Name:        a.Labels["alertname"],
+			Fingerprint: *a.Fingerprint,
+		})
+	}
+
+	return samples
+}
+
+// expire stops extending the silence once it reached its maximum lifetime or endsAt.
+func (r *SilenceReconciler) expire(ctx context.Context, obj *monitoringv1alpha1.Silence) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if meta.IsStatusConditionTrue(obj.Status.Conditions, monitoringv1alpha1.ConditionExpired) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("silence reached its end of life", "am_id", obj.Status.AlertManagerID)
+
+	if err := r.deactivate(ctx, obj); err != nil {
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionExpired,
+		Status:             metav1.ConditionTrue,
+		Reason:             "LifetimeEnded",
+		Message:            "Silence reached its maximum lifetime or endsAt and is not extended anymore",
+		ObservedGeneration: obj.Generation,
+	})
+
+	if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+		log.Error(err, "unable to update status")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// suspend expires the alertmanager silence while spec.suspend is set. The alertmanager ID stays in the status,
+// so the silence is re-created, or adopted again, once the object is resumed.
+func (r *SilenceReconciler) suspend(ctx context.Context, obj *monitoringv1alpha1.Silence) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if meta.IsStatusConditionTrue(obj.Status.Conditions, monitoringv1alpha1.ConditionSuspended) {
+		log.Info("reconciliation is suspended")
+
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("suspending silence", "am_id", obj.Status.AlertManagerID)
+
+	if err := r.deactivate(ctx, obj); err != nil {
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionSuspended,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Suspended",
+		Message:            "Silence is suspended and expired in alertmanager",
+		ObservedGeneration: obj.Generation,
+	})
+
+	if err := r.patchStatus(ctx, obj, obj.Status.AlertManagerID); err != nil {
+		log.Error(err, "unable to update status")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deactivate expires the alertmanager silence, unless it already ended, and resets the status
+// fields which only describe an active silence.
+func (r *SilenceReconciler) deactivate(ctx context.Context, obj *monitoringv1alpha1.Silence) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if obj.Status.AlertManagerID != "" {
+		response, err := r.AlertManager.GetSilence(obj.Status.AlertManagerID)
+		if err == nil && *response.GetPayload().Status.State != models.SilenceStatusStateExpired {
+			log.Info("expiring alertmanager silence", "am_id", obj.Status.AlertManagerID)
+
+			if err := r.AlertManager.DeleteSilence(obj.Status.AlertManagerID); err != nil {
+				log.Error(err, "unable to expire alertmanager silence", "am_id", obj.Status.AlertManagerID)
+
+				return err
+			}
+		}
+	}
+
+	obj.Status.Active = false
+	obj.Status.MutedAlerts = 0
+	obj.Status.MutedAlertSamples = nil
+	obj.Status.MatchingNothingSince = nil
+	meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionMatchesNothing)
+
+	return nil
+}
+
+// extendAt returns the moment the silence ending at endsAt should be extended.
+// Silences which can't be extended anymore are revisited once they reach their end of life.
+func (r *SilenceReconciler) extendAt(obj *monitoringv1alpha1.Silence, endsAt time.Time) time.Time {
+	return extendAt(r.AlertManager, r.ExtendThreshold, obj, endsAt)
+}
+
+// requeueAfter returns the delay until the given moment, but never longer than the reconciliation interval,
+// so changes made directly in alertmanager are still noticed.
+func (r *SilenceReconciler) requeueAfter(t time.Time) time.Duration {
+	return requeueAfter(r.Interval, t)
+}
+
+func extendAt(am *alertmanager.AlertManager, extendThreshold float64, obj *monitoringv1alpha1.Silence, endsAt time.Time) time.Time {
+	expiresAt := obj.ExpiresAt()
+	if expiresAt != nil && !endsAt.Before(*expiresAt) {
+		return *expiresAt
+	}
+
+	threshold := time.Duration(float64(am.Duration(obj)) * extendThreshold)
+
+	return endsAt.Add(-threshold)
+}
+
+func requeueAfter(interval time.Duration, t time.Time) time.Duration {
+	d := time.Until(t)
+
+	switch {
+	case d > interval:
+		return interval
+	case d < time.Second:
+		return time.Second
+	}
+
+	return d
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Silences owned by other objects need no extra watches: the garbage collector deletes them
+// together with their owner and the finalizer takes care of the alertmanager silence.
 func (r *SilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &monitoringv1alpha1.Silence{}, templateRefField,
+		func(obj client.Object) []string {
+			s, ok := obj.(*monitoringv1alpha1.Silence)
+			if !ok || s.Spec.TemplateRef == nil {
+				return nil
+			}
+
+			return []string{s.Spec.TemplateRef.Name}
+		})
+	if err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&monitoringv1alpha1.Silence{}).
-		Named("silence").
-		Owns(&monitoringv1alpha1.Silence{}).
-		Complete(r)
+		Watches(&monitoringv1alpha1.SilenceTemplate{}, handler.EnqueueRequestsFromMapFunc(r.silencesForTemplate)).
+		Named("silence")
+
+	if r.Recover != nil {
+		b = b.WatchesRawSource(source.Channel(r.Recover, &handler.EnqueueRequestForObject{}))
+	}
+
+	return b.Complete(r)
 }
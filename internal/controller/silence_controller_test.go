@@ -18,11 +18,15 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,7 +55,12 @@ var _ = Describe("Silence Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: monitoringv1alpha1.SilenceSpec{
+						Comment: "test",
+						Matchers: monitoringv1alpha1.Matchers{
+							{Name: "alertname", Value: "Test", MatchType: monitoringv1alpha1.MatchEqual},
+						},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -82,3 +91,206 @@ var _ = Describe("Silence Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Silence Controller with alertmanager", func() {
+	var (
+		am         *fakeAlertManager
+		reconciler *SilenceReconciler
+	)
+
+	key := types.NamespacedName{Namespace: "default", Name: "test-lifecycle"}
+
+	get := func() *monitoringv1alpha1.Silence {
+		s := &monitoringv1alpha1.Silence{}
+		Expect(k8sClient.Get(ctx, key, s)).To(Succeed())
+
+		return s
+	}
+
+	reconcileSilence := func() error {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+
+		return err
+	}
+
+	// create creates the silence and reconciles it until the alertmanager silence is applied
+	create := func(spec monitoringv1alpha1.SilenceSpec) string {
+		spec.Comment = "test"
+		spec.Matchers = monitoringv1alpha1.Matchers{
+			{Name: "alertname", Value: "Lifecycle", MatchType: monitoringv1alpha1.MatchEqual},
+		}
+
+		Expect(k8sClient.Create(ctx, &monitoringv1alpha1.Silence{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       spec,
+		})).To(Succeed())
+		Expect(reconcileSilence()).To(Succeed())
+		Expect(reconcileSilence()).To(Succeed())
+
+		id := get().Status.AlertManagerID
+		Expect(am.active()).To(ConsistOf(id))
+
+		return id
+	}
+
+	update := func(mutate func(s *monitoringv1alpha1.Silence)) {
+		s := get()
+		mutate(s)
+		Expect(k8sClient.Update(ctx, s)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		am = newFakeAlertManager()
+		reconciler = &SilenceReconciler{
+			Client:             k8sClient,
+			Scheme:             k8sClient.Scheme(),
+			AlertManager:       am.client(),
+			Interval:           time.Minute,
+			ExtendThreshold:    0.25,
+			GetSilenceAttempts: 1,
+		}
+	})
+
+	AfterEach(func() {
+		s := &monitoringv1alpha1.Silence{}
+		if err := k8sClient.Get(ctx, key, s); err == nil {
+			s.Finalizers = nil
+			Expect(k8sClient.Update(ctx, s)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, s))).To(Succeed())
+		}
+
+		am.Close()
+	})
+
+	It("records the applied matchers in the status", func() {
+		create(monitoringv1alpha1.SilenceSpec{MatcherExpressions: []string{`severity=~"info|warning"`}})
+
+		Expect(get().Status.AppliedMatchers).To(Equal(monitoringv1alpha1.Matchers{
+			{Name: "alertname", Value: "Lifecycle", MatchType: monitoringv1alpha1.MatchEqual},
+			{Name: "severity", Value: "info|warning", MatchType: monitoringv1alpha1.MatchRegexp},
+		}))
+	})
+
+	It("expires the silence while suspended and re-creates it once resumed", func() {
+		id := create(monitoringv1alpha1.SilenceSpec{})
+
+		update(func(s *monitoringv1alpha1.Silence) { s.Spec.Suspend = true })
+		Expect(reconcileSilence()).To(Succeed())
+
+		Expect(am.active()).To(BeEmpty())
+		s := get()
+		Expect(meta.IsStatusConditionTrue(s.Status.Conditions, monitoringv1alpha1.ConditionSuspended)).To(BeTrue())
+		Expect(s.Status.AlertManagerID).To(Equal(id))
+		Expect(s.Status.Active).To(BeFalse())
+
+		// Suspended silences are left alone
+		Expect(reconcileSilence()).To(Succeed())
+		Expect(am.active()).To(BeEmpty())
+
+		update(func(s *monitoringv1alpha1.Silence) { s.Spec.Suspend = false })
+		Expect(reconcileSilence()).To(Succeed())
+
+		s = get()
+		Expect(meta.FindStatusCondition(s.Status.Conditions, monitoringv1alpha1.ConditionSuspended)).To(BeNil())
+		Expect(am.active()).To(ConsistOf(s.Status.AlertManagerID))
+	})
+
+	It("keeps a replacement silence whose status patch failed and records it on retry", func() {
+		id := create(monitoringv1alpha1.SilenceSpec{})
+
+		update(func(s *monitoringv1alpha1.Silence) {
+			s.Spec.Matchers[0].Value = "Changed"
+		})
+
+		reconciler.Client = failingStatusClient{Client: k8sClient}
+		Expect(reconcileSilence()).NotTo(Succeed())
+
+		// Alertmanager expired the recorded silence when replacing it, the replacement keeps muting the alerts
+		active := am.active()
+		Expect(active).To(HaveLen(1))
+		Expect(active[0]).NotTo(Equal(id))
+		Expect(get().Status.AlertManagerID).To(Equal(id))
+
+		reconciler.Client = k8sClient
+		Expect(reconcileSilence()).To(Succeed())
+
+		Expect(get().Status.AlertManagerID).To(Equal(active[0]))
+		Expect(am.active()).To(ConsistOf(active[0]))
+	})
+
+	Context("when the silence is deleted", func() {
+		var recorder *record.FakeRecorder
+
+		deleted := func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, key, &monitoringv1alpha1.Silence{}))
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			reconciler.Recorder = recorder
+			reconciler.DeletionTimeout = time.Hour
+		})
+
+		It("expires the alertmanager silence", func() {
+			create(monitoringv1alpha1.SilenceSpec{})
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileSilence()).To(Succeed())
+
+			Expect(am.active()).To(BeEmpty())
+			Expect(deleted()).To(BeTrue())
+		})
+
+		It("leaves the alertmanager silence running with the Orphan policy", func() {
+			id := create(monitoringv1alpha1.SilenceSpec{DeletionPolicy: monitoringv1alpha1.DeletionPolicyOrphan})
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileSilence()).To(Succeed())
+
+			Expect(am.active()).To(ConsistOf(id))
+			Expect(deleted()).To(BeTrue())
+		})
+
+		It("keeps the finalizer while expiring fails within the deletion timeout", func() {
+			id := create(monitoringv1alpha1.SilenceSpec{})
+			am.failLabel = "alertname"
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileSilence()).NotTo(Succeed())
+
+			Expect(am.active()).To(ConsistOf(id))
+			Expect(get().Finalizers).To(ContainElement(monitoringv1alpha1.SilenceFinalizer))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("gives up expiring once the deletion timeout passed", func() {
+			id := create(monitoringv1alpha1.SilenceSpec{})
+			am.failLabel = "alertname"
+			reconciler.DeletionTimeout = 0
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileSilence()).To(Succeed())
+
+			Expect(am.active()).To(ConsistOf(id))
+			Expect(deleted()).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring("ExpireFailed")))
+		})
+	})
+})
+
+// failingStatusClient fails all status patches, e.g. like an API server which is unavailable.
+type failingStatusClient struct {
+	client.Client
+}
+
+func (c failingStatusClient) Status() client.SubResourceWriter {
+	return failingStatusWriter{SubResourceWriter: c.Client.Status()}
+}
+
+type failingStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (failingStatusWriter) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return errors.NewServiceUnavailable("injected")
+}
@@ -0,0 +1,501 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+	"github.com/silence-operator/silence-operator/internal/alertmanager"
+)
+
+// SilenceGroupReconciler reconciles a SilenceGroup object
+type SilenceGroupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	AlertManager *alertmanager.AlertManager
+	Interval     time.Duration
+
+	// ExtendThreshold is the fraction of the silence duration left when the silence gets extended.
+	ExtendThreshold float64
+
+	// DryRun previews all silence groups as if they had spec.dryRun set.
+	DryRun bool
+
+	// DeletionTimeout is how long expiring the alertmanager silences of a deleted group is retried.
+	// Afterwards the finalizer is removed anyway and a Warning event is recorded.
+	DeletionTimeout time.Duration
+
+	Recorder record.EventRecorder
+
+	// Recover receives the silence groups to reconcile right away after alertmanager lost silences.
+	Recover <-chan event.GenericEvent
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silencegroups,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silencegroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=silencegroups/finalizers,verbs=update
+
+// Reconcile keeps one alertmanager silence per matcher set of the group, tracked by the key of the matcher set.
+// If any of them can't be applied, the silences created during the same attempt are deleted again
+// and the status keeps pointing to the previously applied ones, or to their replacements
+// if alertmanager already expired them.
+func (r *SilenceGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	obj := &monitoringv1alpha1.SilenceGroup{}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !obj.DeletionTimestamp.IsZero() {
+		if err := r.expireOnDeletion(ctx, obj); err != nil {
+			// Retried with the backoff of the controller
+			return ctrl.Result{}, err
+		}
+
+		if _, err := removeFinalizer(ctx, r.Client, obj, monitoringv1alpha1.SilenceGroupFinalizer); err != nil {
+			log.Error(err, "unable to remove finalizer from silence group")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	finalizerAdded, err := addFinalizer(ctx, r.Client, obj, monitoringv1alpha1.SilenceGroupFinalizer)
+	if err != nil {
+		log.Error(err, "unable to add finalizer to silence group")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	if finalizerAdded {
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	if obj.Spec.Suspend {
+		return r.suspend(ctx, obj)
+	}
+
+	for i, matchers := range obj.Spec.MatcherSets {
+		if err := matchers.Validate(); err != nil {
+			log.Error(err, "invalid matcher set", "matcher_set", i)
+
+			meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+				Type:               monitoringv1alpha1.ConditionApplied,
+				Status:             metav1.ConditionFalse,
+				Reason:             "InvalidMatchers",
+				Message:            fmt.Sprintf("matcher set %d: %v", i, err),
+				ObservedGeneration: obj.Generation,
+			})
+
+			if err := r.patchStatus(ctx, obj, obj.Status.RecordedIDs()); err != nil {
+				log.Error(err, "unable to update status")
+
+				return ctrl.Result{RequeueAfter: r.Interval}, err
+			}
+
+			return ctrl.Result{RequeueAfter: r.Interval}, nil
+		}
+	}
+
+	if obj.Spec.DryRun || r.DryRun {
+		return r.preview(ctx, obj)
+	}
+
+	previous := obj.Status.RecordedIDs()
+	changed := obj.Generation != obj.Status.LastAppliedGeneration
+
+	silences := make([]monitoringv1alpha1.SilenceGroupSilence, 0, len(obj.Spec.MatcherSets))
+	created := []string{}
+	next := time.Now().Add(r.Interval)
+
+	for i, matchers := range obj.Spec.MatcherSets {
+		key := matchers.Key()
+
+		// Identical matcher sets share their silence
+		if slices.ContainsFunc(silences, func(s monitoringv1alpha1.SilenceGroupSilence) bool { return s.Key == key }) {
+			continue
+		}
+
+		id, extend, isNew, err := r.apply(ctx, obj.Member(i), changed)
+		if err != nil {
+			log.Error(err, "unable to apply silence of the group, rolling back", "matcher_set", i)
+
+			r.deleteSilences(ctx, created)
+
+			// Alertmanager already expired the silences which were replaced during this attempt,
+			// so their replacements are kept and recorded
+			recorded := obj.RecordedSilences()
+			for _, s := range silences {
+				if !slices.Contains(created, s.AlertManagerID) {
+					recorded[s.Key] = s.AlertManagerID
+				}
+			}
+
+			obj.Status.Silences = sortedSilences(recorded)
+
+			meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+				Type:               monitoringv1alpha1.ConditionApplied,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ApplyFailed",
+				Message:            fmt.Sprintf("matcher set %d: %v", i, err),
+				ObservedGeneration: obj.Generation,
+			})
+
+			if err := r.patchStatus(ctx, obj, previous); err != nil {
+				log.Error(err, "unable to update status")
+			}
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+
+		if isNew {
+			created = append(created, id)
+		}
+
+		silences = append(silences, monitoringv1alpha1.SilenceGroupSilence{Key: key, AlertManagerID: id})
+
+		if extend.Before(next) {
+			next = extend
+		}
+	}
+
+	result := ctrl.Result{RequeueAfter: requeueAfter(r.Interval, next)}
+
+	conditionsChanged := meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		Message:            "All silences of the group are applied",
+		ObservedGeneration: obj.Generation,
+	})
+
+	conditionsChanged = meta.RemoveStatusCondition(&obj.Status.Conditions,
+		monitoringv1alpha1.ConditionSuspended) || conditionsChanged
+
+	if meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionDryRun) {
+		obj.Status.MatchingAlerts = 0
+		obj.Status.MatchingAlertSamples = nil
+		conditionsChanged = true
+	}
+
+	if slices.Equal(obj.Status.Silences, silences) && !changed && !conditionsChanged {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(silences))
+	for _, s := range silences {
+		ids = append(ids, s.AlertManagerID)
+	}
+
+	obj.Status.Silences = silences
+	obj.Status.LastAppliedGeneration = obj.Generation
+
+	if err := r.patchStatus(ctx, obj, previous); err != nil {
+		log.Error(err, "unable to update status, rolling back")
+
+		// Silences which are not recorded would be created again by the next attempt
+		r.deleteSilences(ctx, slices.DeleteFunc(slices.Clone(ids), func(id string) bool {
+			return slices.Contains(previous, id)
+		}))
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	// Silences of matcher sets which were removed from the group are not needed anymore
+	stale := slices.DeleteFunc(slices.Clone(previous), func(id string) bool {
+		return slices.Contains(ids, id)
+	})
+	r.deleteSilences(ctx, stale)
+
+	return result, nil
+}
+
+// sortedSilences returns the recorded silences ordered by key.
+func sortedSilences(recorded map[string]string) []monitoringv1alpha1.SilenceGroupSilence {
+	silences := make([]monitoringv1alpha1.SilenceGroupSilence, 0, len(recorded))
+
+	for _, key := range slices.Sorted(maps.Keys(recorded)) {
+		silences = append(silences, monitoringv1alpha1.SilenceGroupSilence{Key: key, AlertManagerID: recorded[key]})
+	}
+
+	return silences
+}
+
+// patchStatus patches the status computed on obj. Conflicts are retried against the latest version
+// of the object, as long as it still records the alertmanager silences recordedIDs.
+func (r *SilenceGroupReconciler) patchStatus(
+	ctx context.Context, obj *monitoringv1alpha1.SilenceGroup, recordedIDs []string,
+) error {
+	status := obj.Status.DeepCopy()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &monitoringv1alpha1.SilenceGroup{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+
+		if !slices.Equal(latest.Status.RecordedIDs(), recordedIDs) {
+			return errSilenceReplaced
+		}
+
+		patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		latest.Status = *status
+
+		if err := r.Status().Patch(ctx, latest, patch); err != nil {
+			return err
+		}
+
+		obj.ResourceVersion = latest.ResourceVersion
+
+		return nil
+	})
+}
+
+// apply creates or updates the alertmanager silence of a group member. It returns the silence ID,
+// the moment it has to be extended and whether the silence was created by this call.
+func (r *SilenceGroupReconciler) apply(
+	ctx context.Context, member *monitoringv1alpha1.Silence, force bool,
+) (string, time.Time, bool, error) {
+	var startsAt *strfmt.DateTime
+
+	if member.Status.AlertManagerID != "" {
+		response, err := r.AlertManager.GetSilence(member.Status.AlertManagerID)
+
+		var notFound *silence.GetSilenceNotFound
+
+		switch {
+		case errors.As(err, &notFound):
+			member.Status.AlertManagerID = ""
+		case err != nil:
+			return "", time.Time{}, false, err
+		case *response.GetPayload().Status.State != models.SilenceStatusStateExpired:
+			s := response.GetPayload()
+			startsAt = s.StartsAt
+
+			extend := extendAt(r.AlertManager, r.ExtendThreshold, member, time.Time(*s.EndsAt))
+			if !force && alertmanager.MatchersEqual(s.Matchers, member.Spec.Matchers) && time.Now().Before(extend) {
+				return member.Status.AlertManagerID, extend, false, nil
+			}
+		}
+	}
+
+	id, err := r.AlertManager.UpsertSilence(ctx, member, member.Spec.Matchers, startsAt)
+	if err != nil {
+		return "", time.Time{}, false, err
+	}
+
+	extend := extendAt(r.AlertManager, r.ExtendThreshold, member, r.AlertManager.EndsAt(member, time.Now()))
+
+	// UpsertSilence sets the ID of an adopted silence, so an empty ID means the silence was just created.
+	// A silence replaced by alertmanager on update is not new: the previous one is already expired.
+	return id, extend, member.Status.AlertManagerID == "", nil
+}
+
+// suspend expires the alertmanager silences of the group while spec.suspend is set. The IDs stay in the status,
+// so the silences are re-created once the group is resumed.
+func (r *SilenceGroupReconciler) suspend(ctx context.Context, obj *monitoringv1alpha1.SilenceGroup) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if meta.IsStatusConditionTrue(obj.Status.Conditions, monitoringv1alpha1.ConditionSuspended) {
+		log.Info("reconciliation is suspended")
+
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("suspending silence group")
+
+	for _, id := range obj.Status.RecordedIDs() {
+		if err := r.expireSilence(ctx, id); err != nil {
+			log.Error(err, "unable to expire alertmanager silence", "am_id", id)
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+	}
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionSuspended,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Suspended",
+		Message:            "Silence group is suspended and its silences are expired in alertmanager",
+		ObservedGeneration: obj.Generation,
+	})
+
+	if err := r.patchStatus(ctx, obj, obj.Status.RecordedIDs()); err != nil {
+		log.Error(err, "unable to update status")
+
+		return ctrl.Result{RequeueAfter: r.Interval}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// preview reports the alerts the silences of the group would mute without applying them.
+func (r *SilenceGroupReconciler) preview(ctx context.Context, obj *monitoringv1alpha1.SilenceGroup) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	alerts := models.GettableAlerts{}
+
+	for i, matchers := range obj.Spec.MatcherSets {
+		matching, err := r.AlertManager.MatchingAlerts(matchers)
+		if err != nil {
+			log.Error(err, "unable to evaluate silence group matchers", "matcher_set", i)
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+
+		// An alert matching several matcher sets is muted once
+		for _, a := range matching {
+			if !slices.ContainsFunc(alerts, func(b *models.GettableAlert) bool { return *a.Fingerprint == *b.Fingerprint }) {
+				alerts = append(alerts, a)
+			}
+		}
+	}
+
+	before := obj.Status.DeepCopy()
+
+	meta.RemoveStatusCondition(&obj.Status.Conditions, monitoringv1alpha1.ConditionSuspended)
+
+	obj.Status.MatchingAlerts = len(alerts)
+	obj.Status.MatchingAlertSamples = alertSamples(alerts)
+
+	meta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Preview",
+		Message:            fmt.Sprintf("Silence group is not applied, it would mute %d alerts", len(alerts)),
+		ObservedGeneration: obj.Generation,
+	})
+
+	if !equality.Semantic.DeepEqual(before, &obj.Status) {
+		log.Info("dry run, silence group is not applied", "matching_alerts", len(alerts))
+
+		if err := r.patchStatus(ctx, obj, obj.Status.RecordedIDs()); err != nil {
+			log.Error(err, "unable to update status")
+
+			return ctrl.Result{RequeueAfter: r.Interval}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// expireOnDeletion expires the alertmanager silences of a deleted group.
+// Errors are returned for retrying until the deletion timeout has passed, then the silences are given up.
+func (r *SilenceGroupReconciler) expireOnDeletion(ctx context.Context, obj *monitoringv1alpha1.SilenceGroup) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	var errs []error
+
+	for _, id := range obj.Status.RecordedIDs() {
+		if err := r.expireSilence(ctx, id); err != nil {
+			log.Error(err, "unable to delete silence in alertmanager", "am_id", id)
+
+			errs = append(errs, err)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err == nil || time.Since(obj.DeletionTimestamp.Time) < r.DeletionTimeout {
+		return err
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, "ExpireFailed",
+			"Gave up expiring alertmanager silences after %s, they end on their own: %v", r.DeletionTimeout, err)
+	}
+
+	return nil
+}
+
+// expireSilence expires the alertmanager silence unless it already ended or is gone.
+func (r *SilenceGroupReconciler) expireSilence(ctx context.Context, id string) error {
+	response, err := r.AlertManager.GetSilence(id)
+
+	var notFound *silence.GetSilenceNotFound
+
+	switch {
+	case errors.As(err, &notFound):
+		return nil
+	case err != nil:
+		return err
+	case *response.GetPayload().Status.State == models.SilenceStatusStateExpired:
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("expiring alertmanager silence", "am_id", id)
+
+	err = r.AlertManager.DeleteSilence(id)
+
+	var deleteNotFound *silence.DeleteSilenceNotFound
+	if errors.As(err, &deleteNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// deleteSilences deletes the given alertmanager silences. Errors are only logged,
+// the silences end on their own once they are not extended anymore.
+func (r *SilenceGroupReconciler) deleteSilences(ctx context.Context, ids []string) {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, id := range ids {
+		log.Info("deleting alertmanager silence", "am_id", id)
+
+		if err := r.AlertManager.DeleteSilence(id); err != nil {
+			log.Error(err, "unable to delete silence in alertmanager", "am_id", id)
+		}
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SilenceGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&monitoringv1alpha1.SilenceGroup{}).
+		Named("silencegroup")
+
+	if r.Recover != nil {
+		b = b.WatchesRawSource(source.Channel(r.Recover, &handler.EnqueueRequestForObject{}))
+	}
+
+	return b.Complete(r)
+}
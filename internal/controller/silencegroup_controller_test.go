@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "github.com/silence-operator/silence-operator/api/v1alpha1"
+)
+
+var _ = Describe("SilenceGroup Controller", func() {
+	var (
+		am         *fakeAlertManager
+		reconciler *SilenceGroupReconciler
+	)
+
+	key := types.NamespacedName{Namespace: "default", Name: "test-group"}
+
+	matcherSet := func(name, value string) monitoringv1alpha1.Matchers {
+		return monitoringv1alpha1.Matchers{{Name: name, Value: value, MatchType: monitoringv1alpha1.MatchEqual}}
+	}
+
+	get := func() *monitoringv1alpha1.SilenceGroup {
+		group := &monitoringv1alpha1.SilenceGroup{}
+		Expect(k8sClient.Get(ctx, key, group)).To(Succeed())
+
+		return group
+	}
+
+	reconcileGroup := func() error {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+
+		return err
+	}
+
+	// create creates the group and reconciles it once, which only adds the finalizer
+	create := func(sets ...monitoringv1alpha1.Matchers) {
+		Expect(k8sClient.Create(ctx, &monitoringv1alpha1.SilenceGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       monitoringv1alpha1.SilenceGroupSpec{Comment: "test", MatcherSets: sets},
+		})).To(Succeed())
+		Expect(reconcileGroup()).To(Succeed())
+	}
+
+	update := func(mutate func(group *monitoringv1alpha1.SilenceGroup)) {
+		group := get()
+		mutate(group)
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+	}
+
+	setMatcherSets := func(sets ...monitoringv1alpha1.Matchers) {
+		update(func(group *monitoringv1alpha1.SilenceGroup) { group.Spec.MatcherSets = sets })
+	}
+
+	deleted := func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, key, &monitoringv1alpha1.SilenceGroup{}))
+	}
+
+	recorded := func() map[string]string {
+		ids := map[string]string{}
+		for _, s := range get().Status.Silences {
+			ids[s.Key] = s.AlertManagerID
+		}
+
+		return ids
+	}
+
+	BeforeEach(func() {
+		am = newFakeAlertManager()
+		reconciler = &SilenceGroupReconciler{
+			Client:          k8sClient,
+			Scheme:          k8sClient.Scheme(),
+			AlertManager:    am.client(),
+			Interval:        time.Minute,
+			ExtendThreshold: 0.25,
+			DeletionTimeout: time.Hour,
+			Recorder:        record.NewFakeRecorder(10),
+		}
+	})
+
+	AfterEach(func() {
+		group := &monitoringv1alpha1.SilenceGroup{}
+		if err := k8sClient.Get(ctx, key, group); err == nil {
+			group.Finalizers = nil
+			Expect(k8sClient.Update(ctx, group)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, group))).To(Succeed())
+		}
+
+		am.Close()
+	})
+
+	It("creates one silence per matcher set", func() {
+		a, b := matcherSet("alertname", "A"), matcherSet("alertname", "B")
+		create(a, b)
+		Expect(reconcileGroup()).To(Succeed())
+
+		ids := recorded()
+		Expect(ids).To(HaveLen(2))
+		Expect(am.active()).To(ConsistOf(ids[a.Key()], ids[b.Key()]))
+		Expect(meta.IsStatusConditionTrue(get().Status.Conditions, monitoringv1alpha1.ConditionApplied)).To(BeTrue())
+	})
+
+	It("keeps the silences when the matcher sets are reordered", func() {
+		a, b := matcherSet("alertname", "A"), matcherSet("alertname", "B")
+		create(a, b)
+		Expect(reconcileGroup()).To(Succeed())
+		ids := recorded()
+
+		setMatcherSets(b, a)
+		Expect(reconcileGroup()).To(Succeed())
+
+		Expect(recorded()).To(Equal(ids))
+		Expect(am.active()).To(ConsistOf(ids[a.Key()], ids[b.Key()]))
+	})
+
+	It("deletes the silences of removed matcher sets", func() {
+		a, b := matcherSet("alertname", "A"), matcherSet("alertname", "B")
+		create(a, b)
+		Expect(reconcileGroup()).To(Succeed())
+		ids := recorded()
+
+		setMatcherSets(a)
+		Expect(reconcileGroup()).To(Succeed())
+
+		Expect(recorded()).To(Equal(map[string]string{a.Key(): ids[a.Key()]}))
+		Expect(am.active()).To(ConsistOf(ids[a.Key()]))
+	})
+
+	It("rolls back the silences created during a failed attempt", func() {
+		am.failLabel = "team"
+		create(matcherSet("alertname", "A"), matcherSet("team", "ops"))
+		Expect(reconcileGroup()).NotTo(Succeed())
+
+		Expect(am.active()).To(BeEmpty())
+		Expect(recorded()).To(BeEmpty())
+		Expect(meta.IsStatusConditionFalse(get().Status.Conditions, monitoringv1alpha1.ConditionApplied)).To(BeTrue())
+	})
+
+	It("records the silences replaced during a failed attempt", func() {
+		a, b := matcherSet("alertname", "A"), matcherSet("team", "ops")
+		create(a, b)
+		Expect(reconcileGroup()).To(Succeed())
+		ids := recorded()
+
+		// Alertmanager replaces the expired silence of a on update, then applying b fails
+		am.expire(ids[a.Key()])
+		am.failLabel = "team"
+		Expect(reconcileGroup()).NotTo(Succeed())
+
+		after := recorded()
+		Expect(after[a.Key()]).NotTo(Equal(ids[a.Key()]))
+		Expect(after[b.Key()]).To(Equal(ids[b.Key()]))
+		Expect(am.active()).To(ConsistOf(after[a.Key()], ids[b.Key()]))
+	})
+
+	It("expires the silences while suspended and re-creates them once resumed", func() {
+		a, b := matcherSet("alertname", "A"), matcherSet("alertname", "B")
+		create(a, b)
+		Expect(reconcileGroup()).To(Succeed())
+		ids := recorded()
+
+		update(func(group *monitoringv1alpha1.SilenceGroup) { group.Spec.Suspend = true })
+		Expect(reconcileGroup()).To(Succeed())
+
+		Expect(am.active()).To(BeEmpty())
+		Expect(recorded()).To(Equal(ids))
+		Expect(meta.IsStatusConditionTrue(get().Status.Conditions, monitoringv1alpha1.ConditionSuspended)).To(BeTrue())
+
+		// Suspended groups are left alone
+		Expect(reconcileGroup()).To(Succeed())
+		Expect(am.active()).To(BeEmpty())
+
+		update(func(group *monitoringv1alpha1.SilenceGroup) { group.Spec.Suspend = false })
+		Expect(reconcileGroup()).To(Succeed())
+
+		after := recorded()
+		Expect(am.active()).To(ConsistOf(after[a.Key()], after[b.Key()]))
+		Expect(meta.FindStatusCondition(get().Status.Conditions, monitoringv1alpha1.ConditionSuspended)).To(BeNil())
+	})
+
+	It("previews the group in dry run", func() {
+		create(matcherSet("alertname", "A"))
+		update(func(group *monitoringv1alpha1.SilenceGroup) { group.Spec.DryRun = true })
+		Expect(reconcileGroup()).To(Succeed())
+
+		Expect(am.active()).To(BeEmpty())
+		Expect(recorded()).To(BeEmpty())
+
+		condition := meta.FindStatusCondition(get().Status.Conditions, monitoringv1alpha1.ConditionDryRun)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Message).To(ContainSubstring("would mute 0 alerts"))
+
+		update(func(group *monitoringv1alpha1.SilenceGroup) { group.Spec.DryRun = false })
+		Expect(reconcileGroup()).To(Succeed())
+
+		Expect(am.active()).To(HaveLen(1))
+		Expect(meta.FindStatusCondition(get().Status.Conditions, monitoringv1alpha1.ConditionDryRun)).To(BeNil())
+	})
+
+	Context("when the group is deleted", func() {
+		It("expires the silences", func() {
+			create(matcherSet("alertname", "A"), matcherSet("alertname", "B"))
+			Expect(reconcileGroup()).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileGroup()).To(Succeed())
+
+			Expect(am.active()).To(BeEmpty())
+			Expect(deleted()).To(BeTrue())
+		})
+
+		It("keeps the finalizer while expiring fails within the deletion timeout", func() {
+			create(matcherSet("alertname", "A"), matcherSet("team", "ops"))
+			Expect(reconcileGroup()).To(Succeed())
+			ids := recorded()
+			am.failLabel = "team"
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileGroup()).NotTo(Succeed())
+
+			Expect(am.active()).To(ConsistOf(ids[matcherSet("team", "ops").Key()]))
+			Expect(get().Finalizers).To(ContainElement(monitoringv1alpha1.SilenceGroupFinalizer))
+		})
+
+		It("gives up expiring once the deletion timeout passed", func() {
+			create(matcherSet("team", "ops"))
+			Expect(reconcileGroup()).To(Succeed())
+			am.failLabel = "team"
+			reconciler.DeletionTimeout = 0
+
+			Expect(k8sClient.Delete(ctx, get())).To(Succeed())
+			Expect(reconcileGroup()).To(Succeed())
+
+			Expect(am.active()).To(HaveLen(1))
+			Expect(deleted()).To(BeTrue())
+			Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("ExpireFailed")))
+		})
+	})
+})